@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/go-resty/resty/v2"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"os"
@@ -34,24 +35,41 @@ func (c Connector) NewClient(opts ...Option) (*Client, error) {
 
 // Client is the main struct to use to interact with the Akeneo API
 type Client struct {
-	connector    Connector
-	baseURL      *url.URL
-	httpClient   *http.Client
-	token        string            // token is the access token
-	refreshToken string            // refreshToken is the refresh token
-	tokenExp     time.Time         // tokenExp is the token expiration time,5 minutes before the actual expiration
-	osVersion    int               // osVersion is the version of the OS,default pim 6
-	retryCNT     int               // retryCNT is the retry count
-	limiter      ratelimit.Limiter // limiter, default 5 requests per second
-	Auth         AuthService
-	Product      ProductService
-	Family       FamilyService
-	Attribute    AttributeService
-	Category     CategoryService
-	Channel      ChannelService
-	Locale       LocaleService
-	MediaFile    MediaFileService
-	ProductModel ProductModelService
+	connector         Connector
+	baseURL           *url.URL
+	httpClient        *http.Client
+	tokenMu           sync.RWMutex      // tokenMu guards token, refreshToken and tokenExp, the token is refreshed while requests are running
+	token             string            // token is the access token
+	refreshToken      string            // refreshToken is the refresh token
+	tokenExp          time.Time         // tokenExp is the token expiration time,5 minutes before the actual expiration
+	osVersion         int               // osVersion is the version of the OS,detected during init when not set
+	systemInfo        SystemInfo        // systemInfo is the system information detected during init
+	appToken          bool              // appToken is true when the token is a static app token, which is never refreshed
+	productIDCacheTTL time.Duration     // productIDCacheTTL is the TTL of the product identifier and uuid cache
+	middlewares       []Middleware      // middlewares wrap the transport of the http client, the first one is the outermost
+	rest              *resty.Client     // rest is the client used by all the requests, built on httpClient during init
+	logger            *slog.Logger      // logger is nil when logging is disabled
+	hooks             []Hooks           // hooks are called on the client events
+	cassette          *Cassette         // cassette records or replays the requests, innermost middleware
+	cache             *responseCache    // cache caches the GET responses of the schema resources, nil when disabled
+	retryCNT          int               // retryCNT is the retry count
+	limiter           ratelimit.Limiter // limiter, default 5 requests per second, may be shared between clients
+	Auth              AuthService
+	Product           ProductService
+	Family            FamilyService
+	Attribute         AttributeService
+	Category          CategoryService
+	Channel           ChannelService
+	Currency          CurrencyService
+	Locale            LocaleService
+	MediaFile         MediaFileService
+	ProductModel      ProductModelService
+	MeasurementFamily MeasurementFamilyService
+	ReferenceEntity   ReferenceEntityService
+	AssetFamily       AssetFamilyService
+	PublishedProduct  PublishedProductService
+	Job               JobService
+	Catalog           CatalogService
 }
 
 func (c *Client) validate() error {
@@ -59,6 +77,10 @@ func (c *Client) validate() error {
 		return errors.New("baseURL is nil")
 	}
 	switch {
+	case c.appToken && c.token == "":
+		return errors.New("app token is empty")
+	case c.appToken:
+		// app tokens do not need the connection credentials
 	case c.connector.ClientID == "":
 		return errors.New("clientID is empty")
 	case c.connector.Secret == "":
@@ -69,7 +91,7 @@ func (c *Client) validate() error {
 		return errors.New("password is empty")
 	default:
 	}
-	if _, ok := pimVersionMap[c.osVersion]; !ok {
+	if _, ok := pimVersionMap[c.osVersion]; !ok && c.osVersion != 0 {
 		return errors.Errorf("invalid osVersion %d", c.osVersion)
 	}
 	return nil
@@ -79,8 +101,36 @@ func (c *Client) init() error {
 	if c.limiter == nil {
 		c.limiter = ratelimit.New(defaultRateLimit, ratelimit.WithoutSlack, ratelimit.Per(time.Second))
 	}
-	if err := c.Auth.GrantByPassword(); err != nil {
-		return err
+	middlewares := c.middlewares
+	if c.logger != nil {
+		// innermost, to log every attempt as sent
+		middlewares = append(middlewares[:len(middlewares):len(middlewares)], loggingMiddleware(c.logger))
+	}
+	if limiter, ok := c.limiter.(FeedbackLimiter); ok {
+		middlewares = append(middlewares[:len(middlewares):len(middlewares)], feedbackMiddleware(limiter))
+	}
+	if c.cassette != nil {
+		middlewares = append(middlewares[:len(middlewares):len(middlewares)], c.cassette.Middleware())
+	}
+	c.httpClient.Transport = chainMiddlewares(c.httpClient.Transport, middlewares)
+	c.rest = resty.NewWithClient(c.httpClient).
+		SetRetryCount(c.retryCNT).
+		SetRetryWaitTime(defaultRetryWaitTime).
+		SetRetryMaxWaitTime(defaultRetryMaxWaitTime).
+		AddRetryCondition(func(r *resty.Response, err error) bool {
+			return r != nil && r.StatusCode() == http.StatusTooManyRequests
+		}).
+		SetRetryAfter(retryAfter).
+		AddRetryHook(c.observeRetry)
+	if !c.appToken {
+		if err := c.Auth.GrantByPassword(); err != nil {
+			return err
+		}
+	}
+	// the detection never fails the init, the default version is used when nothing can be detected
+	_ = c.detectSystemInfo()
+	if c.osVersion == 0 {
+		c.osVersion = defaultVersion
 	}
 	return nil
 }
@@ -96,7 +146,6 @@ func NewClient(con Connector, opts ...Option) (*Client, error) {
 			},
 		},
 		connector: con,
-		osVersion: defaultVersion,
 		retryCNT:  defaultRetry,
 	}
 	for _, opt := range opts {
@@ -107,14 +156,23 @@ func NewClient(con Connector, opts ...Option) (*Client, error) {
 	}
 	// Set services
 	c.Auth = &authOp{c, sync.Mutex{}}
-	c.Product = &productOp{c}
+	product := &productOp{client: c}
+	product.resolver = NewProductIDResolver(product, c.productIDCacheTTL)
+	c.Product = product
 	c.Family = &familyOp{c}
 	c.Attribute = &attributeOp{c}
 	c.Category = &categoryOp{c}
 	c.Channel = &channelOp{c}
+	c.Currency = &currencyOp{c}
 	c.Locale = &localeOp{c}
 	c.MediaFile = &mediaOp{c}
 	c.ProductModel = &productModelOp{c}
+	c.MeasurementFamily = &measurementFamilyOp{c}
+	c.ReferenceEntity = &referenceEntityOp{c}
+	c.AssetFamily = &assetFamilyOp{c}
+	c.PublishedProduct = &publishedProductOp{c}
+	c.Job = &jobOp{c}
+	c.Catalog = &catalogOp{c}
 	if err := c.init(); err != nil {
 		return nil, err
 	}
@@ -138,13 +196,29 @@ func WithRateLimit(limit int, t time.Duration) Option {
 	}
 }
 
-// WithVersion sets the version of the Akeneo API
+// WithVersion sets the version of the Akeneo API, the version is detected from the system information when not set
 func WithVersion(v int) Option {
 	return func(c *Client) {
 		c.osVersion = v
 	}
 }
 
+// WithAppToken authenticates with the access token of an Akeneo App instead of the connection credentials,
+// see AppCallbackHandler to get one, app tokens never expire so they are not refreshed
+func WithAppToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+		c.appToken = true
+	}
+}
+
+// WithProductIDCacheTTL sets the TTL of the product identifier and uuid cache, default 1 hour
+func WithProductIDCacheTTL(ttl time.Duration) Option {
+	return func(c *Client) {
+		c.productIDCacheTTL = ttl
+	}
+}
+
 // WithRetry sets the retry count of the Akeneo API
 func WithRetry(cnt int) Option {
 	return func(c *Client) {
@@ -164,21 +238,21 @@ func (c *Client) createAndDoGetHeaders(method, relPath string, opts, data, resul
 	// Make the full url based on the relative path
 	u := c.baseURL.ResolveReference(rel)
 
+	contentType := defaultContentType
+	var lines *PatchProductResponse // lines is the result of a collection request, decoded line by line
+	if body, ok := data.(collection); ok {
+		contentType = defaultCollectionType
+		data = []byte(body)
+		lines, _ = result.(*PatchProductResponse)
+	}
 	var errResp ErrorResponse
-	client := resty.NewWithClient(c.httpClient).
-		SetRetryCount(c.retryCNT).
-		SetRetryWaitTime(defaultRetryWaitTime).
-		SetRetryMaxWaitTime(defaultRetryMaxWaitTime).
-		AddRetryCondition(func(r *resty.Response, err error) bool {
-			return r != nil && r.StatusCode() == http.StatusTooManyRequests
-		})
-	request := client.R().
-		SetHeader("Content-Type", defaultContentType).
+	request := c.rest.R().
+		SetHeader("Content-Type", contentType).
 		SetHeader("Accept", defaultAccept).
 		SetHeader("User-Agent", defaultUserAgent).
-		SetAuthToken(c.token).
+		SetAuthToken(c.accessToken()).
 		SetError(&errResp)
-	if result != nil {
+	if result != nil && lines == nil {
 		request.SetResult(result)
 	}
 	if opts != nil {
@@ -213,9 +287,24 @@ func (c *Client) createAndDoGetHeaders(method, relPath string, opts, data, resul
 	if data != nil {
 		request.SetBody(data)
 	}
+	cacheKey, cacheable := c.cache.cacheKey(u)
+	var cached *CachedResponse
+	if cacheable && method == http.MethodGet {
+		var fresh bool
+		if cached, fresh = c.cache.lookup(cacheKey); fresh {
+			return cached.Header.Clone(), cached.decode(result)
+		} else if cached != nil {
+			c.cache.revalidate(request, cached)
+		}
+	}
 	// rate limit
-	c.limiter.Take()
+	wait := c.takeLimiter()
+	start := time.Now()
 	resp, err := request.Execute(method, u.String())
+	c.observeRequest(method, u.String(), resp, err, wait, time.Since(start))
+	if cacheable && method != http.MethodGet {
+		c.invalidatePath(u.Path)
+	}
 	if err != nil {
 		return http.Header{}, errors.Wrap(err, "resty execute error")
 	}
@@ -232,6 +321,19 @@ func (c *Client) createAndDoGetHeaders(method, relPath string, opts, data, resul
 		// default response
 		return http.Header{}, errors.Errorf("request error : %s", errResp.Message)
 	}
+	if lines != nil {
+		if err := lines.decodeLines(resp.Body()); err != nil {
+			return http.Header{}, err
+		}
+	}
+	if cacheable && method == http.MethodGet {
+		if resp.StatusCode() == http.StatusNotModified && cached != nil {
+			renewed := *cached
+			c.cache.storeResponse(cacheKey, &renewed)
+			return cached.Header.Clone(), cached.decode(result)
+		}
+		c.cache.store(cacheKey, resp)
+	}
 	return resp.Header(), nil
 }
 
@@ -239,20 +341,15 @@ func (c *Client) download(downloadURL string, fp string) error {
 	if err := c.Auth.AutoRefreshToken(); err != nil {
 		return err
 	}
-	client := resty.NewWithClient(c.httpClient).
-		SetRetryCount(c.retryCNT).
-		SetRetryWaitTime(defaultRetryWaitTime).
-		SetRetryMaxWaitTime(defaultRetryMaxWaitTime).
-		AddRetryCondition(func(r *resty.Response, err error) bool {
-			return r != nil && r.StatusCode() == http.StatusTooManyRequests
-		})
-	request := client.R().
+	request := c.rest.R().
 		SetHeader("User-Agent", defaultUserAgent).
-		SetAuthToken(c.token)
+		SetAuthToken(c.accessToken())
 	// rate limit
-	c.limiter.Take()
+	wait := c.takeLimiter()
+	start := time.Now()
 	resp, err := request.
 		Get(downloadURL)
+	c.observeRequest(http.MethodGet, downloadURL, resp, err, wait, time.Since(start))
 	if err != nil {
 		return errors.Wrap(err, "resty execute get error")
 	}
@@ -290,22 +387,17 @@ func (c *Client) upload(endpoint string, data any, contentType string) (string,
 	}
 	pathURL, _ := url.Parse(endpoint)
 	uploadURL := c.baseURL.ResolveReference(pathURL).String()
-	client := resty.NewWithClient(c.httpClient).
-		SetRetryCount(c.retryCNT).
-		SetRetryWaitTime(defaultRetryWaitTime).
-		SetRetryMaxWaitTime(defaultRetryMaxWaitTime).
-		AddRetryCondition(func(r *resty.Response, err error) bool {
-			return r != nil && r.StatusCode() == http.StatusTooManyRequests
-		})
-	request := client.R().
+	request := c.rest.R().
 		SetHeader("User-Agent", defaultUserAgent).
-		SetAuthToken(c.token).
+		SetAuthToken(c.accessToken()).
 		SetHeader("Content-Type", contentType)
 	// rate limit
-	c.limiter.Take()
+	wait := c.takeLimiter()
+	start := time.Now()
 	resp, err := request.
 		SetBody(data).
 		Post(uploadURL)
+	c.observeRequest(http.MethodPost, uploadURL, resp, err, wait, time.Since(start))
 	if err != nil {
 		return "", errors.Wrap(err, "resty execute post error")
 	}
@@ -348,5 +440,58 @@ func (c *Client) PATCH(relPath string, ops, data, result any) error {
 	if err != nil {
 		return errors.Wrap(err, "PATCH error")
 	}
+	c.observeBulkResponse(relPath, result)
+	return nil
+}
+
+// collection is the body of a bulk PATCH request, one json object per line
+type collection []byte
+
+// newCollection encodes the items of a slice as a collection
+func newCollection(items any) (collection, error) {
+	v := reflect.ValueOf(items)
+	if v.Kind() != reflect.Slice {
+		return nil, errors.New("items must be a slice")
+	}
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for i := 0; i < v.Len(); i++ {
+		// the encoder ends every item with a new line
+		if err := encoder.Encode(v.Index(i).Interface()); err != nil {
+			return nil, errors.Wrapf(err, "unable to encode item %d", i)
+		}
+	}
+	return body.Bytes(), nil
+}
+
+// patchCollection sends the items of a slice to a bulk PATCH endpoint, one json object per line,
+// and returns the status of every line
+func (c *Client) patchCollection(relPath string, items any) (PatchProductResponse, error) {
+	body, err := newCollection(items)
+	if err != nil {
+		return nil, errors.Wrap(err, "PATCH error")
+	}
+	result := new(PatchProductResponse)
+	if err := c.PATCH(relPath, nil, body, result); err != nil {
+		return nil, err
+	}
+	return *result, nil
+}
+
+// DELETE creates a delete request and execute it
+func (c *Client) DELETE(relPath string, ops, data, result any) error {
+	_, err := c.createAndDoGetHeaders(http.MethodDelete, relPath, ops, data, result)
+	if err != nil {
+		return errors.Wrap(err, "DELETE error")
+	}
+	return nil
+}
+
+// PUT creates a put request and execute it
+func (c *Client) PUT(relPath string, ops, data, result any) error {
+	_, err := c.createAndDoGetHeaders(http.MethodPut, relPath, ops, data, result)
+	if err != nil {
+		return errors.Wrap(err, "PUT error")
+	}
 	return nil
 }
@@ -0,0 +1,422 @@
+package goakeneo
+
+import (
+	"net/url"
+	"path"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	assetFamilyBasePath    = "/api/rest/v1/asset-families"
+	assetMediaFileBasePath = "/api/rest/v1/asset-media-files"
+)
+
+// AssetFamilyService is the interface to interact with the Akeneo Asset Manager API
+// see: https://api.akeneo.com/api-reference.html#Assetfamily
+type AssetFamilyService interface {
+	ListWithPagination(options any) ([]AssetFamily, Links, error)
+	GetAssetFamily(code string) (*AssetFamily, error)
+	UpdateOrCreateAssetFamily(code string, family AssetFamily) error
+	ListAttributes(familyCode string) ([]AssetAttribute, error)
+	GetAttribute(familyCode, attributeCode string) (*AssetAttribute, error)
+	UpdateOrCreateAttribute(familyCode, attributeCode string, attribute AssetAttribute) error
+	ListAttributeOptions(familyCode, attributeCode string) ([]AssetAttributeOption, error)
+	GetAttributeOption(familyCode, attributeCode, optionCode string) (*AssetAttributeOption, error)
+	UpdateOrCreateAttributeOption(familyCode, attributeCode, optionCode string, option AssetAttributeOption) error
+	ListAssetsWithPagination(familyCode string, options any) ([]Asset, Links, error)
+	GetAsset(familyCode, assetCode string) (*Asset, error)
+	UpdateOrCreateAsset(familyCode, assetCode string, asset Asset) error
+	UpsertAssets(familyCode string, assets []Asset) (PatchProductResponse, error)
+	DeleteAsset(familyCode, assetCode string) error
+	UploadMediaFile(filePath string) (string, error)
+	DownloadMediaFile(code, filePath string) error
+	ResolveAssetCollection(familyCode string, value ProductValue, channel, locale string) ([]AssetMediaLink, error)
+}
+
+type assetFamilyOp struct {
+	client *Client
+}
+
+// ListWithPagination lists asset families with pagination
+func (a *assetFamilyOp) ListWithPagination(options any) ([]AssetFamily, Links, error) {
+	response := new(AssetFamiliesResponse)
+	if err := a.client.GET(
+		assetFamilyBasePath,
+		options,
+		nil,
+		response,
+	); err != nil {
+		return nil, Links{}, err
+	}
+	return response.Embedded.Items, response.Links, nil
+}
+
+// GetAssetFamily gets an asset family by code
+func (a *assetFamilyOp) GetAssetFamily(code string) (*AssetFamily, error) {
+	sourcePath := path.Join(assetFamilyBasePath, code)
+	family := new(AssetFamily)
+	if err := a.client.GET(
+		sourcePath,
+		nil,
+		nil,
+		family,
+	); err != nil {
+		return nil, err
+	}
+	return family, nil
+}
+
+// UpdateOrCreateAssetFamily updates or creates an asset family
+func (a *assetFamilyOp) UpdateOrCreateAssetFamily(code string, family AssetFamily) error {
+	sourcePath := path.Join(assetFamilyBasePath, code)
+	if err := a.client.PATCH(
+		sourcePath,
+		nil,
+		family,
+		nil,
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ListAttributes lists the attributes of an asset family, the endpoint is not paginated
+func (a *assetFamilyOp) ListAttributes(familyCode string) ([]AssetAttribute, error) {
+	sourcePath := path.Join(assetFamilyBasePath, familyCode, "attributes")
+	var attributes []AssetAttribute
+	if err := a.client.GET(
+		sourcePath,
+		nil,
+		nil,
+		&attributes,
+	); err != nil {
+		return nil, err
+	}
+	return attributes, nil
+}
+
+// GetAttribute gets an asset attribute by code
+func (a *assetFamilyOp) GetAttribute(familyCode, attributeCode string) (*AssetAttribute, error) {
+	sourcePath := path.Join(assetFamilyBasePath, familyCode, "attributes", attributeCode)
+	attribute := new(AssetAttribute)
+	if err := a.client.GET(
+		sourcePath,
+		nil,
+		nil,
+		attribute,
+	); err != nil {
+		return nil, err
+	}
+	return attribute, nil
+}
+
+// UpdateOrCreateAttribute updates or creates an asset attribute
+func (a *assetFamilyOp) UpdateOrCreateAttribute(familyCode, attributeCode string, attribute AssetAttribute) error {
+	sourcePath := path.Join(assetFamilyBasePath, familyCode, "attributes", attributeCode)
+	if err := a.client.PATCH(
+		sourcePath,
+		nil,
+		attribute,
+		nil,
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ListAttributeOptions lists the options of an asset attribute, the endpoint is not paginated
+func (a *assetFamilyOp) ListAttributeOptions(familyCode, attributeCode string) ([]AssetAttributeOption, error) {
+	sourcePath := path.Join(assetFamilyBasePath, familyCode, "attributes", attributeCode, "options")
+	var options []AssetAttributeOption
+	if err := a.client.GET(
+		sourcePath,
+		nil,
+		nil,
+		&options,
+	); err != nil {
+		return nil, err
+	}
+	return options, nil
+}
+
+// GetAttributeOption gets an asset attribute option by code
+func (a *assetFamilyOp) GetAttributeOption(familyCode, attributeCode, optionCode string) (*AssetAttributeOption, error) {
+	sourcePath := path.Join(assetFamilyBasePath, familyCode, "attributes", attributeCode, "options", optionCode)
+	option := new(AssetAttributeOption)
+	if err := a.client.GET(
+		sourcePath,
+		nil,
+		nil,
+		option,
+	); err != nil {
+		return nil, err
+	}
+	return option, nil
+}
+
+// UpdateOrCreateAttributeOption updates or creates an asset attribute option
+func (a *assetFamilyOp) UpdateOrCreateAttributeOption(familyCode, attributeCode, optionCode string, option AssetAttributeOption) error {
+	sourcePath := path.Join(assetFamilyBasePath, familyCode, "attributes", attributeCode, "options", optionCode)
+	if err := a.client.PATCH(
+		sourcePath,
+		nil,
+		option,
+		nil,
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ListAssetsWithPagination lists the assets of an asset family with search_after pagination
+// options should be AssetListOptions or url.Values
+func (a *assetFamilyOp) ListAssetsWithPagination(familyCode string, options any) ([]Asset, Links, error) {
+	sourcePath := path.Join(assetFamilyBasePath, familyCode, "assets")
+	response := new(AssetsResponse)
+	if err := a.client.GET(
+		sourcePath,
+		options,
+		nil,
+		response,
+	); err != nil {
+		return nil, Links{}, err
+	}
+	return response.Embedded.Items, response.Links, nil
+}
+
+// GetAsset gets an asset by code
+func (a *assetFamilyOp) GetAsset(familyCode, assetCode string) (*Asset, error) {
+	sourcePath := path.Join(assetFamilyBasePath, familyCode, "assets", assetCode)
+	asset := new(Asset)
+	if err := a.client.GET(
+		sourcePath,
+		nil,
+		nil,
+		asset,
+	); err != nil {
+		return nil, err
+	}
+	return asset, nil
+}
+
+// UpdateOrCreateAsset updates or creates an asset
+func (a *assetFamilyOp) UpdateOrCreateAsset(familyCode, assetCode string, asset Asset) error {
+	sourcePath := path.Join(assetFamilyBasePath, familyCode, "assets", assetCode)
+	if err := a.client.PATCH(
+		sourcePath,
+		nil,
+		asset,
+		nil,
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpsertAssets updates or creates several assets of an asset family at once
+func (a *assetFamilyOp) UpsertAssets(familyCode string, assets []Asset) (PatchProductResponse, error) {
+	sourcePath := path.Join(assetFamilyBasePath, familyCode, "assets")
+	result := new(PatchProductResponse)
+	if err := a.client.PATCH(
+		sourcePath,
+		nil,
+		assets,
+		result,
+	); err != nil {
+		return nil, err
+	}
+	return *result, nil
+}
+
+// DeleteAsset deletes an asset by code
+func (a *assetFamilyOp) DeleteAsset(familyCode, assetCode string) error {
+	sourcePath := path.Join(assetFamilyBasePath, familyCode, "assets", assetCode)
+	if err := a.client.DELETE(
+		sourcePath,
+		nil,
+		nil,
+		nil,
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UploadMediaFile uploads an asset media file, returns the code of the media file
+func (a *assetFamilyOp) UploadMediaFile(filePath string) (string, error) {
+	body, contentType, err := multipartFile(filePath, nil)
+	if err != nil {
+		return "", err
+	}
+	location, err := a.client.upload(assetMediaFileBasePath, body, contentType)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to upload file %s", filePath)
+	}
+	return mediaFileCodeFromLocation(location, assetMediaFileBasePath), nil
+}
+
+// DownloadMediaFile downloads an asset media file by code
+func (a *assetFamilyOp) DownloadMediaFile(code, filePath string) error {
+	sourcePath := path.Join(assetMediaFileBasePath, code)
+	sourceP, _ := url.Parse(sourcePath)
+	downloadURL := a.client.baseURL.ResolveReference(sourceP).String()
+	if err := a.client.download(downloadURL, filePath); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ResolveAssetCollection resolves the main media of the assets of a pim_catalog_asset_collection product value
+// familyCode is the asset family of the product attribute, i.e. Attribute.ReferenceDataName
+// the main media value matching the channel and locale is used, falling back to the non scopable and non localizable ones.
+// the assets are fetched by batches of 100 codes with ListAssetsWithPagination.
+// the assets that are not found, i.e. deleted since the product was saved, and the assets without a main media
+// for the channel and locale are skipped alike, so the links are the media to show, in the order of the value
+func (a *assetFamilyOp) ResolveAssetCollection(familyCode string, value ProductValue, channel, locale string) ([]AssetMediaLink, error) {
+	codes, err := assetCodes(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(codes) == 0 {
+		return nil, nil
+	}
+	family, err := a.GetAssetFamily(familyCode)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get asset family %s", familyCode)
+	}
+	attribute, err := a.GetAttribute(familyCode, family.AttributeAsMainMedia)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get main media attribute %s", family.AttributeAsMainMedia)
+	}
+	assets, err := a.listAssetsByCodes(familyCode, codes)
+	if err != nil {
+		return nil, err
+	}
+	links := make([]AssetMediaLink, 0, len(codes))
+	for _, code := range codes {
+		asset, ok := assets[code]
+		if !ok {
+			continue
+		}
+		link, ok := assetMainMediaLink(asset, *attribute, channel, locale)
+		if !ok {
+			continue
+		}
+		links = append(links, link)
+	}
+	return links, nil
+}
+
+// listAssetsByCodes gets the assets of a family by batches of "code IN" searches, keyed by code
+func (a *assetFamilyOp) listAssetsByCodes(familyCode string, codes []string) (map[string]Asset, error) {
+	codes = uniqueStrings(codes)
+	assets := make(map[string]Asset, len(codes))
+	for start := 0; start < len(codes); start += defaultGetManyBatchSize {
+		batch := codes[start:min(start+defaultGetManyBatchSize, len(codes))]
+		sf := make(SearchFilter)
+		sf.Add("code", "IN", batch)
+		items, links, err := a.ListAssetsWithPagination(familyCode, AssetListOptions{Search: sf.String()})
+		for {
+			if err != nil {
+				return nil, errors.Wrapf(err, "unable to list the assets of asset family %s", familyCode)
+			}
+			for _, asset := range items {
+				assets[asset.Code] = asset
+			}
+			if !links.HasNext() {
+				break
+			}
+			items, links, err = a.ListAssetsWithPagination(familyCode, links.NextOptions())
+		}
+	}
+	return assets, nil
+}
+
+// AssetMediaLink is the main media of an asset
+// for media files, Code is the asset media file code to use with AssetFamilyService.DownloadMediaFile
+type AssetMediaLink struct {
+	AssetCode   string
+	Type        string // AssetAttributeTypeMediaFile or AssetAttributeTypeMediaLink
+	Code        string
+	DownloadURL string
+}
+
+// assetMainMediaLink builds the media link of an asset from its main media attribute
+func assetMainMediaLink(asset Asset, attribute AssetAttribute, channel, locale string) (AssetMediaLink, bool) {
+	values := asset.Values[attribute.Code]
+	var value RecordValue
+	found := false
+	for _, key := range [][2]string{{channel, locale}, {channel, ""}, {"", locale}, {"", ""}} {
+		if value, found = findRecordValue(values, key[0], key[1]); found {
+			break
+		}
+	}
+	if !found {
+		return AssetMediaLink{}, false
+	}
+	data, ok := value.Text()
+	if !ok || data == "" {
+		return AssetMediaLink{}, false
+	}
+	link := AssetMediaLink{
+		AssetCode: asset.Code,
+		Type:      attribute.Type,
+		Code:      data,
+	}
+	switch attribute.Type {
+	case AssetAttributeTypeMediaLink:
+		link.DownloadURL = data
+		if attribute.Prefix != nil {
+			link.DownloadURL = *attribute.Prefix + link.DownloadURL
+		}
+		if attribute.Suffix != nil {
+			link.DownloadURL += *attribute.Suffix
+		}
+	default:
+		link.DownloadURL = value.DownloadURL()
+	}
+	return link, true
+}
+
+// assetCodes returns the asset codes of an asset collection product value
+func assetCodes(value ProductValue) ([]string, error) {
+	switch d := value.Data.(type) {
+	case nil:
+		return nil, nil
+	case []string:
+		return d, nil
+	case []interface{}:
+		codes := make([]string, len(d))
+		for i, item := range d {
+			s, ok := item.(string)
+			if !ok {
+				return nil, errors.New("invalid asset collection elem, should be string")
+			}
+			codes[i] = s
+		}
+		return codes, nil
+	default:
+		return nil, errors.Errorf("invalid asset collection data %v", value.Data)
+	}
+}
+
+// AssetFamiliesResponse is the struct for an akeneo asset families response
+type AssetFamiliesResponse struct {
+	Links    Links            `json:"_links,omitempty" mapstructure:"_links"`
+	Embedded assetFamilyItems `json:"_embedded,omitempty" mapstructure:"_embedded"`
+}
+
+type assetFamilyItems struct {
+	Items []AssetFamily `json:"items,omitempty" mapstructure:"items"`
+}
+
+// AssetsResponse is the struct for an akeneo assets response
+type AssetsResponse struct {
+	Links    Links      `json:"_links,omitempty" mapstructure:"_links"`
+	Embedded assetItems `json:"_embedded,omitempty" mapstructure:"_embedded"`
+}
+
+type assetItems struct {
+	Items []Asset `json:"items,omitempty" mapstructure:"items"`
+}
@@ -0,0 +1,94 @@
+package goakeneo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssetMainMediaLink(t *testing.T) {
+	raw := `{
+		"code": "sku_54628_telescope",
+		"values": {
+			"media_preview": [
+				{"locale": null, "channel": "ecommerce", "data": "sku_54628_picture1.jpg"},
+				{"locale": null, "channel": null, "data": "sku_54628_default.jpg"}
+			]
+		}
+	}`
+	var asset Asset
+	assert.NoError(t, json.Unmarshal([]byte(raw), &asset))
+	prefix := "https://cdn.example.com/"
+	attribute := AssetAttribute{Code: "media_preview", Type: AssetAttributeTypeMediaLink, Prefix: &prefix}
+
+	link, ok := assetMainMediaLink(asset, attribute, "ecommerce", "en_US")
+	assert.True(t, ok)
+	assert.Equal(t, "https://cdn.example.com/sku_54628_picture1.jpg", link.DownloadURL)
+
+	link, ok = assetMainMediaLink(asset, attribute, "print", "en_US")
+	assert.True(t, ok)
+	assert.Equal(t, "https://cdn.example.com/sku_54628_default.jpg", link.DownloadURL)
+
+	codes, err := assetCodes(ProductValue{Data: []interface{}{"a", "b"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, codes)
+}
+
+func TestAssetFamilyOp_ResolveAssetCollection(t *testing.T) {
+	var searches, gets int
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case assetFamilyBasePath + "/packshots":
+			writeJSON(w, http.StatusOK, AssetFamily{Code: "packshots", AttributeAsMainMedia: "media"})
+		case assetFamilyBasePath + "/packshots/attributes/media":
+			writeJSON(w, http.StatusOK, AssetAttribute{Code: "media", Type: AssetAttributeTypeMediaLink})
+		case assetFamilyBasePath + "/packshots/assets":
+			searches++
+			var search SearchFilter
+			assert.NoError(t, json.Unmarshal([]byte(r.URL.Query().Get("search")), &search))
+			assert.Equal(t, "IN", search["code"][0]["operator"])
+			codes := search["code"][0]["value"].([]any)
+			assert.LessOrEqual(t, len(codes), 100)
+			resp := AssetsResponse{}
+			for _, code := range codes {
+				if code == "deleted" {
+					continue
+				}
+				if code == "no_media" {
+					resp.Embedded.Items = append(resp.Embedded.Items, Asset{Code: "no_media"})
+					continue
+				}
+				resp.Embedded.Items = append(resp.Embedded.Items, Asset{
+					Code:   code.(string),
+					Values: map[string][]RecordValue{"media": {{Data: code.(string) + ".jpg"}}},
+				})
+			}
+			writeJSON(w, http.StatusOK, resp)
+		default:
+			gets++
+			writeJSON(w, http.StatusNotFound, ErrorResponse{Code: http.StatusNotFound, Message: "Resource not found"})
+		}
+	}), WithVersion(AkeneoPimVersion6))
+	codes := make([]any, 150)
+	for i := range codes {
+		codes[i] = fmt.Sprintf("asset_%d", i)
+	}
+	links, err := c.AssetFamily.ResolveAssetCollection("packshots", ProductValue{Data: codes}, "ecommerce", "en_US")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, searches, "the assets are searched by batches of 100")
+	assert.Equal(t, 0, gets)
+	if assert.Len(t, links, 150) {
+		assert.Equal(t, "asset_0", links[0].AssetCode)
+		assert.Equal(t, "asset_149.jpg", links[149].DownloadURL)
+	}
+
+	links, err = c.AssetFamily.ResolveAssetCollection("packshots", ProductValue{Data: []string{"deleted", "asset_1", "no_media", "asset_2"}}, "ecommerce", "en_US")
+	assert.NoError(t, err, "a missing asset is skipped like an asset without media")
+	if assert.Len(t, links, 2) {
+		assert.Equal(t, "asset_1", links[0].AssetCode)
+		assert.Equal(t, "asset_2", links[1].AssetCode)
+	}
+}
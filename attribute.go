@@ -1,6 +1,7 @@
 package goakeneo
 
 import (
+	"context"
 	"path"
 )
 
@@ -13,6 +14,9 @@ type AttributeService interface {
 	ListWithPagination(options any) ([]Attribute, Links, error)
 	GetAttribute(code string, options any) (*Attribute, error)
 	GetAttributeOptions(code string, options any) ([]AttributeOption, Links, error)
+	GetAttributeOption(attributeCode, optionCode string) (*AttributeOption, error)
+	GetMany(ctx context.Context, codes []string, opts GetManyOptions) (GetManyResult[Attribute], error)
+	GetManyAttributeOptions(ctx context.Context, attributeCode string, codes []string, opts GetManyOptions) (GetManyResult[AttributeOption], error)
 }
 
 // attributeOp handles communication with the attribute related methods of the Akeneo API.
@@ -64,6 +68,21 @@ func (c *attributeOp) GetAttributeOptions(code string, options any) ([]Attribute
 	return attributeOptionsResponse.Embedded.Items, attributeOptionsResponse.Links, nil
 }
 
+// GetAttributeOption gets an attribute option by code
+func (c *attributeOp) GetAttributeOption(attributeCode, optionCode string) (*AttributeOption, error) {
+	sourcePath := path.Join(attributeBasePath, attributeCode, "options", optionCode)
+	option := new(AttributeOption)
+	if err := c.client.GET(
+		sourcePath,
+		nil,
+		nil,
+		option,
+	); err != nil {
+		return nil, err
+	}
+	return option, nil
+}
+
 // AttributesResponse is the struct for a akeneo attributes response
 type AttributesResponse struct {
 	Links       Links          `json:"_links" mapstructure:"_links"`
@@ -7,7 +7,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/go-resty/resty/v2"
 	"github.com/pkg/errors"
 )
 
@@ -30,18 +29,42 @@ type authOp struct {
 
 // GrantByPassword authenticates to the Akeneo API using the password grant type
 func (a *authOp) GrantByPassword() error {
-	result := new(authResponse)
 	request := authByPasswordRequest{
 		GrantType: "password",
 		Username:  a.client.connector.UserName,
 		Password:  a.client.connector.Password,
 	}
+	return a.grant(request.GrantType, request)
+}
+
+// GrantByRefreshToken authenticates to the Akeneo API using the refresh token grant type
+func (a *authOp) GrantByRefreshToken() error {
+	request := authByRefreshTokenRequest{
+		GrantType: "refresh_token",
+	}
+	a.client.tokenMu.RLock()
+	request.RefreshToken = a.client.refreshToken
+	a.client.tokenMu.RUnlock()
+	return a.grant(request.GrantType, request)
+}
+
+// grant requests a token and observes the token event
+func (a *authOp) grant(grantType string, request any) error {
+	err := a.doGrant(request)
+	a.client.observeToken(grantType, err)
+	return err
+}
+
+// doGrant requests a token with the client credentials, the request goes through the client transport
+func (a *authOp) doGrant(request any) error {
+	result := new(authResponse)
 	rel, _ := url.Parse(authBasePath)
 	// Make the full url based on the relative path
 	u := a.client.baseURL.ResolveReference(rel)
 	var errResp ErrorResponse
-	_, err := resty.New().R().
+	_, err := a.client.rest.R().
 		SetHeader("Content-Type", defaultContentType).
+		SetHeader("User-Agent", defaultUserAgent).
 		SetHeader("Authorization", base64BasicAuth(a.client.connector.ClientID, a.client.connector.Secret)).
 		SetBody(request).
 		SetResult(result).
@@ -50,44 +73,41 @@ func (a *authOp) GrantByPassword() error {
 	if err != nil {
 		return errors.Wrap(err, "unable to authenticate to the Akeneo API")
 	}
+	if errResp.Message != "" {
+		return errors.Errorf("unable to authenticate to the Akeneo API: %s", errResp.Message)
+	}
 	if err := result.validate(); err != nil {
 		return errors.Wrap(err, "invalid response from the Akeneo API")
 	}
-	if errResp.Message != "" {
-		return errors.Wrapf(err, "unable to authenticate to the Akeneo API: %s", errResp.Message)
-	}
+	a.client.tokenMu.Lock()
+	defer a.client.tokenMu.Unlock()
 	a.client.token = result.AccessToken
 	a.client.refreshToken = result.RefreshToken
 	a.client.tokenExp = time.Now().Add(time.Duration(result.ExpiresIn) * time.Second)
 	return nil
 }
 
-// GrantByRefreshToken authenticates to the Akeneo API using the refresh token grant type
-func (a *authOp) GrantByRefreshToken() error {
-	result := new(authResponse)
-	request := authByRefreshTokenRequest{
-		GrantType:    "refresh_token",
-		RefreshToken: a.client.refreshToken,
-	}
-	if err := a.client.POST(
-		authBasePath,
-		nil,
-		request,
-		result,
-	); err != nil {
-		return err
-	}
-	if err := result.validate(); err != nil {
-		return err
-	}
-	a.client.token = result.AccessToken
-	a.client.refreshToken = result.RefreshToken
-	a.client.tokenExp = time.Now().Add(time.Duration(result.ExpiresIn) * time.Second)
-	return nil
+// tokenExpiration returns the expiration time of the access token
+func (c *Client) tokenExpiration() time.Time {
+	c.tokenMu.RLock()
+	defer c.tokenMu.RUnlock()
+	return c.tokenExp
+}
+
+// accessToken returns the current access token, it may be refreshed concurrently
+func (c *Client) accessToken() string {
+	c.tokenMu.RLock()
+	defer c.tokenMu.RUnlock()
+	return c.token
 }
 
 // ShouldRefreshToken returns true if the token should be refreshed
 func (a *authOp) ShouldRefreshToken() bool {
+	if a.client.appToken {
+		return false
+	}
+	a.client.tokenMu.RLock()
+	defer a.client.tokenMu.RUnlock()
 	// time.Now is 5 minutes before the actual expiration
 	return time.Now().Add(5 * time.Minute).After(a.client.tokenExp)
 }
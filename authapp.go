@@ -0,0 +1,162 @@
+package goakeneo
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/pkg/errors"
+)
+
+const (
+	appAuthorizePath = "connect/apps/v1/authorize"
+	appTokenPath     = "connect/apps/v1/oauth2/token"
+)
+
+// AppToken is the access token of an Akeneo App, use it with WithAppToken
+// app tokens do not expire and have no refresh token
+type AppToken struct {
+	AccessToken string `json:"access_token,omitempty"`
+	TokenType   string `json:"token_type,omitempty"`
+	Scope       string `json:"scope,omitempty"`
+	IDToken     string `json:"id_token,omitempty"` // only with the openid scope
+}
+
+// AppAuthorizeURL builds the url to redirect the user to when the app is activated from the PIM
+// pimURL is the "pim_url" query parameter of the activate url, state should be a random value checked in the callback
+func AppAuthorizeURL(pimURL, clientID string, scopes []string, state string) (string, error) {
+	base, err := url.Parse(pimURL)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid pim url %s", pimURL)
+	}
+	rel, _ := url.Parse(appAuthorizePath)
+	u := base.ResolveReference(rel)
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", clientID)
+	query.Set("scope", strings.Join(scopes, " "))
+	query.Set("state", state)
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// ExchangeAppCode exchanges the authorization code received in the callback for an app access token
+func ExchangeAppCode(pimURL, clientID, clientSecret, code string) (*AppToken, error) {
+	base, err := url.Parse(pimURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid pim url %s", pimURL)
+	}
+	rel, _ := url.Parse(appTokenPath)
+	u := base.ResolveReference(rel)
+	codeIdentifier, err := randomHex(32)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to generate the code identifier")
+	}
+	result := new(AppToken)
+	var errResp ErrorResponse
+	resp, err := resty.New().SetTimeout(defaultHTTPTimeout).R().
+		SetHeader("User-Agent", defaultUserAgent).
+		SetFormData(map[string]string{
+			"client_id":       clientID,
+			"code_identifier": codeIdentifier,
+			"code_challenge":  appCodeChallenge(codeIdentifier, clientSecret),
+			"code":            code,
+			"grant_type":      "authorization_code",
+		}).
+		SetResult(result).
+		SetError(&errResp).
+		Post(u.String())
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to exchange the authorization code")
+	}
+	if resp.IsError() {
+		return nil, errors.Errorf("unable to exchange the authorization code: %s %s", resp.Status(), errResp.Message)
+	}
+	if result.AccessToken == "" {
+		return nil, errors.New("invalid app token response")
+	}
+	return result, nil
+}
+
+// appCodeChallenge returns the code challenge of the token request,
+// the sha256 hex digest of the code identifier followed by the client secret
+func appCodeChallenge(codeIdentifier, clientSecret string) string {
+	sum := sha256.Sum256([]byte(codeIdentifier + clientSecret))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// AppCallbackHandler is the http.Handler of the app callback url,
+// it checks the state, exchanges the authorization code and hands the token to OnToken
+type AppCallbackHandler struct {
+	ClientID     string
+	ClientSecret string
+	// PimURL returns the url of the PIM which sent the request, i.e. the pim_url saved during the activation
+	PimURL func(r *http.Request) (string, error)
+	// ValidateState returns false when the state does not match the one sent to AppAuthorizeURL,
+	// it is required, the requests are rejected when it is nil
+	ValidateState func(r *http.Request, state string) bool
+	// OnToken is called with the app token, it should store it and respond to the user, it is required
+	OnToken func(w http.ResponseWriter, r *http.Request, token *AppToken)
+	// OnError is called when the authorization failed, http.Error is used when nil
+	OnError func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// ServeHTTP handles the callback request
+func (h *AppCallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case h.ValidateState == nil:
+		h.fail(w, r, http.StatusInternalServerError, errors.New("ValidateState is nil, the state can not be checked"))
+		return
+	case h.PimURL == nil:
+		h.fail(w, r, http.StatusInternalServerError, errors.New("PimURL is nil"))
+		return
+	case h.OnToken == nil:
+		h.fail(w, r, http.StatusInternalServerError, errors.New("OnToken is nil"))
+		return
+	}
+	query := r.URL.Query()
+	if e := query.Get("error"); e != "" {
+		h.fail(w, r, http.StatusBadRequest, errors.Errorf("authorization refused: %s %s", e, query.Get("error_description")))
+		return
+	}
+	if !h.ValidateState(r, query.Get("state")) {
+		h.fail(w, r, http.StatusForbidden, errors.New("invalid state"))
+		return
+	}
+	code := query.Get("code")
+	if code == "" {
+		h.fail(w, r, http.StatusBadRequest, errors.New("missing authorization code"))
+		return
+	}
+	pimURL, err := h.PimURL(r)
+	if err != nil {
+		h.fail(w, r, http.StatusBadRequest, errors.Wrap(err, "unable to get the pim url"))
+		return
+	}
+	token, err := ExchangeAppCode(pimURL, h.ClientID, h.ClientSecret, code)
+	if err != nil {
+		h.fail(w, r, http.StatusBadGateway, err)
+		return
+	}
+	h.OnToken(w, r, token)
+}
+
+func (h *AppCallbackHandler) fail(w http.ResponseWriter, r *http.Request, status int, err error) {
+	if h.OnError != nil {
+		h.OnError(w, r, err)
+		return
+	}
+	http.Error(w, err.Error(), status)
+}
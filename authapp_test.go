@@ -0,0 +1,84 @@
+package goakeneo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppAuthorizeURL(t *testing.T) {
+	u, err := AppAuthorizeURL("https://pim.example.com", "app_id", []string{"read_products", "read_catalog_structure"}, "xyz")
+	assert.NoError(t, err)
+	parsed, err := url.Parse(u)
+	assert.NoError(t, err)
+	assert.Equal(t, "/connect/apps/v1/authorize", parsed.Path)
+	assert.Equal(t, "read_products read_catalog_structure", parsed.Query().Get("scope"))
+	assert.Equal(t, "xyz", parsed.Query().Get("state"))
+}
+
+func TestAppCallbackHandler(t *testing.T) {
+	pim := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/"+appTokenPath, r.URL.Path)
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "the_code", r.PostForm.Get("code"))
+		assert.Equal(t, appCodeChallenge(r.PostForm.Get("code_identifier"), "app_secret"), r.PostForm.Get("code_challenge"))
+		writeJSON(w, http.StatusOK, AppToken{AccessToken: "app_token", TokenType: "bearer"})
+	}))
+	defer pim.Close()
+
+	var token *AppToken
+	h := &AppCallbackHandler{
+		ClientID:      "app_id",
+		ClientSecret:  "app_secret",
+		PimURL:        func(r *http.Request) (string, error) { return pim.URL, nil },
+		ValidateState: func(r *http.Request, state string) bool { return state == "xyz" },
+		OnToken: func(w http.ResponseWriter, r *http.Request, t *AppToken) {
+			token = t
+		},
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/callback?code=the_code&state=wrong", nil))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Nil(t, token)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/callback?code=the_code&state=xyz", nil))
+	assert.NotNil(t, token)
+	assert.Equal(t, "app_token", token.AccessToken)
+}
+
+func TestAppCallbackHandler_Config(t *testing.T) {
+	exchanges := 0
+	pim := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		exchanges++
+		writeJSON(w, http.StatusOK, AppToken{AccessToken: "app_token", TokenType: "bearer"})
+	}))
+	defer pim.Close()
+
+	pimURL := func(r *http.Request) (string, error) { return pim.URL, nil }
+	handlers := map[string]*AppCallbackHandler{
+		"without ValidateState": {PimURL: pimURL, OnToken: func(http.ResponseWriter, *http.Request, *AppToken) {}},
+		"without OnToken":       {PimURL: pimURL, ValidateState: func(*http.Request, string) bool { return true }},
+	}
+	for name, h := range handlers {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/callback?code=the_code&state=xyz", nil))
+		assert.Equal(t, http.StatusInternalServerError, rec.Code, name)
+	}
+	assert.Equal(t, 0, exchanges, "the code is not exchanged by a misconfigured handler")
+}
+
+func TestWithAppToken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer app_token", r.Header.Get("Authorization"))
+		writeJSON(w, http.StatusOK, SystemInfo{Version: "7.0.1", Edition: EditionCommunity})
+	}))
+	defer srv.Close()
+	c, err := NewClient(Connector{}, WithBaseURL(srv.URL), WithAppToken("app_token"))
+	assert.NoError(t, err)
+	assert.False(t, c.Auth.ShouldRefreshToken())
+	assert.Equal(t, AkeneoPimVersion7, c.osVersion)
+}
@@ -0,0 +1,243 @@
+package goakeneo
+
+import (
+	"container/list"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultResponseCacheTTL  = 10 * time.Minute
+	defaultResponseCacheSize = 1000
+)
+
+// cacheableResources are the resources cached by the response cache, they change rarely
+var cacheableResources = map[string]bool{
+	"attributes": true, // attribute options included
+	"families":   true, // family variants included
+	"channels":   true,
+	"locales":    true,
+	"categories": true,
+}
+
+// CachedResponse is a GET response stored in a ResponseCache
+type CachedResponse struct {
+	Body         []byte
+	Header       http.Header
+	ETag         string // ETag is sent back in If-None-Match when the response expired
+	LastModified string // LastModified is sent back in If-Modified-Since when the response expired
+	ExpiresAt    time.Time
+}
+
+// ResponseCache stores the GET responses of the client, it must be safe for concurrent use
+// the keys are the request urls with their normalized query, i.e. "https://pim.example.com/api/rest/v1/attributes/color",
+// so a cache can be shared by the clients of several PIMs
+type ResponseCache interface {
+	Get(key string) (*CachedResponse, bool)
+	Set(key string, response *CachedResponse)
+	// DeleteFunc deletes the responses whose key matches
+	DeleteFunc(match func(key string) bool)
+}
+
+// responseCache is the response cache of a client
+type responseCache struct {
+	responses ResponseCache
+	ttl       time.Duration
+}
+
+// WithResponseCache caches the GET responses of the attributes, attribute options, families, family variants,
+// channels, locales and categories for the ttl, default 10 minutes, use NewLRUCache for an in-memory cache.
+// expired responses are revalidated with their ETag or Last-Modified header when the PIM sends one,
+// the writes of the client invalidate the written resource, use Client.Invalidate for the changes made elsewhere
+func WithResponseCache(cache ResponseCache, ttl time.Duration) Option {
+	return func(c *Client) {
+		if ttl <= 0 {
+			ttl = defaultResponseCacheTTL
+		}
+		c.cache = &responseCache{responses: cache, ttl: ttl}
+	}
+}
+
+// Invalidate removes a resource from the response cache, with its sub resources and the lists of the resource,
+// i.e. Invalidate("attributes", "color") removes the color attribute, its options and the attribute lists.
+// an empty code removes the whole resource
+func (c *Client) Invalidate(resource, code string) {
+	if c.cache == nil {
+		return
+	}
+	base := "/api/rest/v1/" + strings.Trim(resource, "/")
+	item := base + "/" + code
+	origin := originOf(c.baseURL)
+	c.cache.responses.DeleteFunc(func(key string) bool {
+		p, _, _ := strings.Cut(key, "?")
+		p, ok := strings.CutPrefix(p, origin)
+		if !ok {
+			return false
+		}
+		if code == "" {
+			return p == base || strings.HasPrefix(p, base+"/")
+		}
+		return p == base || p == item || strings.HasPrefix(p, item+"/")
+	})
+}
+
+// cacheKey returns the cache key of a request, and false when the request is not cacheable
+func (rc *responseCache) cacheKey(u *url.URL) (string, bool) {
+	if rc == nil {
+		return "", false
+	}
+	resource, _ := EndpointOf(http.MethodGet, u.Path)
+	root, _, _ := strings.Cut(resource, "/")
+	if !cacheableResources[root] {
+		return "", false
+	}
+	key := originOf(u) + u.Path
+	if query := normalizeQuery(u.Query()); query != "" {
+		key += "?" + query
+	}
+	return key, true
+}
+
+// originOf returns the scheme and host of an url, the prefix of the cache keys of a PIM
+func originOf(u *url.URL) string {
+	return u.Scheme + "://" + u.Host
+}
+
+// lookup returns the cached response, fresh is false when it has to be revalidated
+func (rc *responseCache) lookup(key string) (cached *CachedResponse, fresh bool) {
+	cached, ok := rc.responses.Get(key)
+	if !ok {
+		return nil, false
+	}
+	return cached, time.Now().Before(cached.ExpiresAt)
+}
+
+// revalidate adds the conditional headers of an expired response to the request
+func (rc *responseCache) revalidate(request *resty.Request, cached *CachedResponse) {
+	if cached.ETag != "" {
+		request.SetHeader("If-None-Match", cached.ETag)
+	}
+	if cached.LastModified != "" {
+		request.SetHeader("If-Modified-Since", cached.LastModified)
+	}
+}
+
+// store caches a successful response
+func (rc *responseCache) store(key string, resp *resty.Response) {
+	if resp.StatusCode() != http.StatusOK {
+		return
+	}
+	rc.storeResponse(key, &CachedResponse{
+		Body:         resp.Body(),
+		Header:       resp.Header().Clone(),
+		ETag:         resp.Header().Get("ETag"),
+		LastModified: resp.Header().Get("Last-Modified"),
+	})
+}
+
+// storeResponse caches a response for the ttl
+func (rc *responseCache) storeResponse(key string, cached *CachedResponse) {
+	cached.ExpiresAt = time.Now().Add(rc.ttl)
+	rc.responses.Set(key, cached)
+}
+
+// decode decodes a cached response into the result
+func (cached *CachedResponse) decode(result any) error {
+	if result == nil || len(cached.Body) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(cached.Body, result); err != nil {
+		return errors.Wrap(err, "unable to decode the cached response")
+	}
+	return nil
+}
+
+// LRUCache is an in-memory ResponseCache evicting the least recently used responses
+type LRUCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List // front is the most recently used
+	entries map[string]*list.Element
+}
+
+type lruEntry struct {
+	key      string
+	response *CachedResponse
+}
+
+// NewLRUCache creates an in-memory cache of size responses, default 1000
+func NewLRUCache(size int) *LRUCache {
+	if size <= 0 {
+		size = defaultResponseCacheSize
+	}
+	return &LRUCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// Get returns a response and marks it as recently used
+func (l *LRUCache) Get(key string) (*CachedResponse, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	e, ok := l.entries[key]
+	if !ok {
+		return nil, false
+	}
+	l.order.MoveToFront(e)
+	return e.Value.(*lruEntry).response, true
+}
+
+// Set stores a response, evicting the least recently used one when the cache is full
+func (l *LRUCache) Set(key string, response *CachedResponse) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if e, ok := l.entries[key]; ok {
+		e.Value.(*lruEntry).response = response
+		l.order.MoveToFront(e)
+		return
+	}
+	l.entries[key] = l.order.PushFront(&lruEntry{key: key, response: response})
+	for l.order.Len() > l.size {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.entries, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// DeleteFunc deletes the responses whose key matches
+func (l *LRUCache) DeleteFunc(match func(key string) bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for key, e := range l.entries {
+		if match(key) {
+			l.order.Remove(e)
+			delete(l.entries, key)
+		}
+	}
+}
+
+// Len returns the number of cached responses
+func (l *LRUCache) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.order.Len()
+}
+
+// invalidatePath invalidates the resource written by a request
+func (c *Client) invalidatePath(p string) {
+	segments := strings.Split(strings.TrimPrefix(strings.Trim(p, "/"), "api/rest/v1/"), "/")
+	code := ""
+	if len(segments) > 1 {
+		code = segments[1]
+	}
+	c.Invalidate(segments[0], code)
+}
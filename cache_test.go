@@ -0,0 +1,108 @@
+package goakeneo
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithResponseCache(t *testing.T) {
+	var gets, notModified int32
+	lru := NewLRUCache(10)
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == channelBasePath+"/ecommerce":
+			atomic.AddInt32(&gets, 1)
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				atomic.AddInt32(&notModified, 1)
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", `"v1"`)
+			writeJSON(w, http.StatusOK, Channel{Code: "ecommerce", Currencies: []string{"EUR"}})
+		case r.Method == http.MethodPatch:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeJSON(w, http.StatusNotFound, ErrorResponse{Code: http.StatusNotFound, Message: "Resource not found"})
+		}
+	}, WithVersion(AkeneoPimVersion6), WithResponseCache(lru, time.Hour))
+
+	for i := 0; i < 3; i++ {
+		channel, err := c.Channel.GetChannel("ecommerce")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"EUR"}, channel.Currencies)
+	}
+	assert.Equal(t, int32(1), gets, "the channel is served from the cache")
+
+	// an expired response is revalidated with its ETag
+	for _, e := range lru.entries {
+		e.Value.(*lruEntry).response.ExpiresAt = time.Time{}
+	}
+	for i := 0; i < 2; i++ {
+		channel, err := c.Channel.GetChannel("ecommerce")
+		assert.NoError(t, err)
+		assert.Equal(t, "ecommerce", channel.Code)
+	}
+	assert.Equal(t, int32(2), gets)
+	assert.Equal(t, int32(1), notModified)
+
+	// a write invalidates the resource
+	assert.NoError(t, c.Channel.UpdateChannel("ecommerce", Channel{Code: "ecommerce"}))
+	_, err := c.Channel.GetChannel("ecommerce")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), gets)
+	assert.Equal(t, int32(1), notModified, "the invalidated response is not revalidated")
+
+	c.Invalidate("channels", "ecommerce")
+	_, err = c.Channel.GetChannel("ecommerce")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(4), gets)
+}
+
+func TestLRUCache(t *testing.T) {
+	l := NewLRUCache(2)
+	l.Set("a", &CachedResponse{})
+	l.Set("b", &CachedResponse{})
+	_, _ = l.Get("a")
+	l.Set("c", &CachedResponse{})
+	_, ok := l.Get("b")
+	assert.False(t, ok, "the least recently used response is evicted")
+	_, ok = l.Get("a")
+	assert.True(t, ok)
+	l.DeleteFunc(func(key string) bool { return key == "a" })
+	assert.Equal(t, 1, l.Len())
+}
+
+func TestWithResponseCache_SharedByPIMs(t *testing.T) {
+	lru := NewLRUCache(10)
+	newPIM := func(currency string, gets *int32) *Client {
+		return newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(gets, 1)
+			writeJSON(w, http.StatusOK, Channel{Code: "ecommerce", Currencies: []string{currency}})
+		}), WithVersion(AkeneoPimVersion6), WithResponseCache(lru, time.Hour))
+	}
+	var euGets, usGets int32
+	eu, us := newPIM("EUR", &euGets), newPIM("USD", &usGets)
+
+	for i := 0; i < 2; i++ {
+		channel, err := eu.Channel.GetChannel("ecommerce")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"EUR"}, channel.Currencies)
+		channel, err = us.Channel.GetChannel("ecommerce")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"USD"}, channel.Currencies, "the response of another PIM is not served")
+	}
+	assert.Equal(t, int32(1), euGets)
+	assert.Equal(t, int32(1), usGets)
+
+	eu.Invalidate("channels", "ecommerce")
+	_, err := eu.Channel.GetChannel("ecommerce")
+	assert.NoError(t, err)
+	_, err = us.Channel.GetChannel("ecommerce")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), euGets)
+	assert.Equal(t, int32(1), usGets, "the invalidation is limited to its PIM")
+}
@@ -0,0 +1,286 @@
+package goakeneo
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// CassetteMode is the mode of a cassette
+type CassetteMode int
+
+const (
+	// CassetteReplay replays the recorded interactions, the requests never reach the network
+	CassetteReplay CassetteMode = iota
+	// CassetteRecord sends the requests and records the interactions, the file is overwritten
+	CassetteRecord
+	// CassetteAuto replays when the file exists and records otherwise
+	CassetteAuto
+)
+
+// sensitiveFields are the json body fields redacted in the cassettes
+var sensitiveFields = map[string]bool{
+	"username":      true,
+	"password":      true,
+	"access_token":  true,
+	"refresh_token": true,
+	"client_secret": true,
+}
+
+// Cassette records the interactions with akeneo to a json file and replays them,
+// the requests are matched on the method, the path and the normalized query, in the recorded order.
+// the tokens and the credentials are redacted, so a replayed client authenticates with a redacted token
+type Cassette struct {
+	path         string
+	mode         CassetteMode
+	mu           sync.Mutex
+	Interactions []Interaction `json:"interactions"`
+	used         []bool
+}
+
+// Interaction is a recorded request and its response
+type Interaction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+// CassetteRequest is a recorded request
+type CassetteRequest struct {
+	Method  string      `json:"method"`
+	Path    string      `json:"path"`
+	Query   string      `json:"query,omitempty"` // the normalized query
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+// CassetteResponse is a recorded response, binary bodies are base64 encoded
+type CassetteResponse struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 string      `json:"body_base64,omitempty"`
+}
+
+// NewCassette opens a cassette, the file is loaded in replay mode and in auto mode when it exists
+func NewCassette(path string, mode CassetteMode) (*Cassette, error) {
+	c := &Cassette{path: path, mode: mode}
+	if mode == CassetteAuto {
+		c.mode = CassetteRecord
+		if _, err := os.Stat(path); err == nil {
+			c.mode = CassetteReplay
+		}
+	}
+	if c.mode != CassetteReplay {
+		return c, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read the cassette %s", path)
+	}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, errors.Wrapf(err, "invalid cassette %s", path)
+	}
+	c.used = make([]bool, len(c.Interactions))
+	return c, nil
+}
+
+// Mode returns the mode of the cassette, auto is resolved to replay or record
+func (c *Cassette) Mode() CassetteMode {
+	return c.mode
+}
+
+// WithCassette records or replays the requests of the client with the cassette,
+// the cassette is the innermost middleware so the other middlewares see the replayed requests
+func WithCassette(cassette *Cassette) Option {
+	return func(c *Client) {
+		c.cassette = cassette
+	}
+}
+
+// Middleware returns the middleware recording or replaying the requests
+func (c *Cassette) Middleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if c.mode == CassetteReplay {
+				return c.replay(req)
+			}
+			return c.record(next, req)
+		})
+	}
+}
+
+// replay returns the first unused interaction matching the request, or the last matching one when all are used
+func (c *Cassette) replay(req *http.Request) (*http.Response, error) {
+	method, path, query := req.Method, req.URL.Path, normalizeQuery(req.URL.Query())
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	found := -1
+	for i, interaction := range c.Interactions {
+		r := interaction.Request
+		if r.Method != method || r.Path != path || r.Query != query {
+			continue
+		}
+		found = i
+		if !c.used[i] {
+			break
+		}
+	}
+	if found < 0 {
+		return nil, errors.Errorf("cassette %s: no interaction recorded for %s %s?%s", c.path, method, path, query)
+	}
+	c.used[found] = true
+	recorded := c.Interactions[found].Response
+	body := []byte(recorded.Body)
+	if recorded.BodyBase64 != "" {
+		var err error
+		if body, err = base64.StdEncoding.DecodeString(recorded.BodyBase64); err != nil {
+			return nil, errors.Wrapf(err, "cassette %s: invalid body", c.path)
+		}
+	}
+	return &http.Response{
+		Status:        http.StatusText(recorded.StatusCode),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        recorded.Headers.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// record sends the request and saves the interaction, the file is written after every interaction
+func (c *Cassette) record(next http.RoundTripper, req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			reqBody, _ = io.ReadAll(body)
+			_ = body.Close()
+		}
+	}
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	interaction := Interaction{
+		Request: CassetteRequest{
+			Method:  req.Method,
+			Path:    req.URL.Path,
+			Query:   normalizeQuery(req.URL.Query()),
+			Headers: redactHeaders(req.Header),
+			Body:    string(redactBody(reqBody)),
+		},
+		Response: CassetteResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    redactHeaders(resp.Header),
+		},
+	}
+	// the body length changes with the redaction
+	interaction.Response.Headers.Del("Content-Length")
+	if respBody = redactBody(respBody); utf8.Valid(respBody) {
+		interaction.Response.Body = string(respBody)
+	} else {
+		interaction.Response.BodyBase64 = base64.StdEncoding.EncodeToString(respBody)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Interactions = append(c.Interactions, interaction)
+	if err := c.save(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// save writes the cassette file
+func (c *Cassette) save() error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "unable to encode the cassette")
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return errors.Wrapf(err, "unable to create the cassette directory of %s", c.path)
+	}
+	if err := os.WriteFile(c.path, data, 0o644); err != nil {
+		return errors.Wrapf(err, "unable to write the cassette %s", c.path)
+	}
+	return nil
+}
+
+// normalizeQuery encodes the query with sorted keys and values, json values are compacted with sorted keys
+func normalizeQuery(query url.Values) string {
+	normalized := url.Values{}
+	for key, values := range query {
+		values = append([]string(nil), values...)
+		for i, v := range values {
+			var decoded any
+			if strings.HasPrefix(v, "{") && json.Unmarshal([]byte(v), &decoded) == nil {
+				if data, err := json.Marshal(decoded); err == nil {
+					values[i] = string(data)
+				}
+			}
+		}
+		sort.Strings(values)
+		normalized[key] = values
+	}
+	return normalized.Encode()
+}
+
+// redactHeaders returns the headers without the cookies and with the authorization redacted
+func redactHeaders(header http.Header) http.Header {
+	redactedHeader := http.Header{}
+	for key, values := range header {
+		switch {
+		case key == "Set-Cookie" || key == "Cookie":
+		case sensitiveHeaders[key]:
+			redactedHeader[key] = []string{redacted}
+		default:
+			redactedHeader[key] = values
+		}
+	}
+	return redactedHeader
+}
+
+// redactBody redacts the sensitive fields of a json object body, other bodies are returned as is
+func redactBody(body []byte) []byte {
+	if !bytes.HasPrefix(bytes.TrimSpace(body), []byte("{")) {
+		return body
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return body
+	}
+	changed := false
+	for key := range fields {
+		if sensitiveFields[key] {
+			fields[key] = json.RawMessage(`"` + redacted + `"`)
+			changed = true
+		}
+	}
+	if !changed {
+		return body
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return body
+	}
+	return data
+}
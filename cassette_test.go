@@ -0,0 +1,62 @@
+package goakeneo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCassette_RecordReplay(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/" + authBasePath:
+			writeJSON(w, http.StatusOK, authResponse{AccessToken: "secret_token", RefreshToken: "secret_refresh", ExpiresIn: 3600, TokenType: "bearer"})
+		case systemInformationBasePath:
+			writeJSON(w, http.StatusOK, SystemInfo{Version: "6.0.1", Edition: EditionCommunity})
+		case channelBasePath + "/ecommerce":
+			writeJSON(w, http.StatusOK, Channel{Code: "ecommerce", Currencies: []string{"EUR"}})
+		default:
+			writeJSON(w, http.StatusNotFound, ErrorResponse{Code: http.StatusNotFound, Message: "Resource not found"})
+		}
+	}))
+	file := filepath.Join(t.TempDir(), "cassette.json")
+	con := Connector{ClientID: "client_id", Secret: "client_secret", UserName: "username", Password: "secret_password"}
+
+	recorder, err := NewCassette(file, CassetteAuto)
+	assert.NoError(t, err)
+	assert.Equal(t, CassetteRecord, recorder.Mode())
+	c, err := NewClient(con, WithBaseURL(srv.URL), WithCassette(recorder))
+	assert.NoError(t, err)
+	channel, err := c.Channel.GetChannel("ecommerce")
+	assert.NoError(t, err)
+	assert.Equal(t, "ecommerce", channel.Code)
+	srv.Close()
+
+	data, err := os.ReadFile(file)
+	assert.NoError(t, err)
+	for _, secret := range []string{"secret_token", "secret_refresh", "secret_password", "Bearer", "Basic"} {
+		assert.NotContains(t, string(data), secret)
+	}
+
+	player, err := NewCassette(file, CassetteAuto)
+	assert.NoError(t, err)
+	assert.Equal(t, CassetteReplay, player.Mode())
+	c, err = NewClient(con, WithBaseURL("http://akeneo.invalid"), WithCassette(player), WithRetry(0))
+	assert.NoError(t, err)
+	assert.Equal(t, AkeneoPimVersion6, c.osVersion)
+	channel, err = c.Channel.GetChannel("ecommerce")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"EUR"}, channel.Currencies)
+	_, err = c.Channel.GetChannel("mobile")
+	assert.Error(t, err, "requests which were not recorded fail")
+}
+
+func TestNormalizeQuery(t *testing.T) {
+	a := normalizeQuery(map[string][]string{"search": {`{"b": [1], "a": 2}`}, "limit": {"10"}})
+	b := normalizeQuery(map[string][]string{"limit": {"10"}, "search": {`{"a":2,"b":[1]}`}})
+	assert.Equal(t, a, b)
+}
@@ -0,0 +1,240 @@
+package goakeneo
+
+import (
+	"encoding/json"
+	"path"
+)
+
+const (
+	catalogBasePath = "/api/rest/v1/catalogs"
+)
+
+// CatalogService is the interface to interact with the Akeneo App Catalog API
+// only available with an app token, see: https://api.akeneo.com/api-reference.html#Catalogs
+type CatalogService interface {
+	ListWithPagination(options any) ([]Catalog, Links, error)
+	GetCatalog(id string) (*Catalog, error)
+	CreateCatalog(name string) (*Catalog, error)
+	UpdateCatalog(id, name string) (*Catalog, error)
+	DeleteCatalog(id string) error
+	GetProductMappingSchema(id string) (json.RawMessage, error)
+	UpdateProductMappingSchema(id string, schema json.RawMessage) error
+	DeleteProductMappingSchema(id string) error
+	ListProductUUIDsWithPagination(id string, options any) ([]string, Links, error)
+	ListProductsWithPagination(id string, options any) ([]Product, Links, error)
+	GetProduct(id, productUUID string) (*Product, error)
+	ListMappedProductsWithPagination(id string, options any) ([]MappedProduct, Links, error)
+}
+
+type catalogOp struct {
+	client *Client
+}
+
+// ListWithPagination lists the catalogs of the app with pagination
+func (c *catalogOp) ListWithPagination(options any) ([]Catalog, Links, error) {
+	response := new(CatalogsResponse)
+	if err := c.client.GET(
+		catalogBasePath,
+		options,
+		nil,
+		response,
+	); err != nil {
+		return nil, Links{}, err
+	}
+	return response.Embedded.Items, response.Links, nil
+}
+
+// GetCatalog gets a catalog by id
+func (c *catalogOp) GetCatalog(id string) (*Catalog, error) {
+	sourcePath := path.Join(catalogBasePath, id)
+	catalog := new(Catalog)
+	if err := c.client.GET(
+		sourcePath,
+		nil,
+		nil,
+		catalog,
+	); err != nil {
+		return nil, err
+	}
+	return catalog, nil
+}
+
+// CreateCatalog creates a catalog, the catalog is disabled until the user enables it in the PIM
+func (c *catalogOp) CreateCatalog(name string) (*Catalog, error) {
+	catalog := new(Catalog)
+	if err := c.client.POST(
+		catalogBasePath,
+		nil,
+		Catalog{Name: name},
+		catalog,
+	); err != nil {
+		return nil, err
+	}
+	return catalog, nil
+}
+
+// UpdateCatalog updates the name of a catalog
+func (c *catalogOp) UpdateCatalog(id, name string) (*Catalog, error) {
+	sourcePath := path.Join(catalogBasePath, id)
+	catalog := new(Catalog)
+	if err := c.client.PATCH(
+		sourcePath,
+		nil,
+		Catalog{Name: name},
+		catalog,
+	); err != nil {
+		return nil, err
+	}
+	return catalog, nil
+}
+
+// DeleteCatalog deletes a catalog by id
+func (c *catalogOp) DeleteCatalog(id string) error {
+	sourcePath := path.Join(catalogBasePath, id)
+	if err := c.client.DELETE(
+		sourcePath,
+		nil,
+		nil,
+		nil,
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetProductMappingSchema gets the product mapping schema of a catalog
+func (c *catalogOp) GetProductMappingSchema(id string) (json.RawMessage, error) {
+	sourcePath := path.Join(catalogBasePath, id, "mapping-schemas", "product")
+	var schema json.RawMessage
+	if err := c.client.GET(
+		sourcePath,
+		nil,
+		nil,
+		&schema,
+	); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// UpdateProductMappingSchema uploads the product mapping schema of a catalog, schema is a JSON schema document
+func (c *catalogOp) UpdateProductMappingSchema(id string, schema json.RawMessage) error {
+	sourcePath := path.Join(catalogBasePath, id, "mapping-schemas", "product")
+	if err := c.client.PUT(
+		sourcePath,
+		nil,
+		schema,
+		nil,
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteProductMappingSchema deletes the product mapping schema of a catalog
+func (c *catalogOp) DeleteProductMappingSchema(id string) error {
+	sourcePath := path.Join(catalogBasePath, id, "mapping-schemas", "product")
+	if err := c.client.DELETE(
+		sourcePath,
+		nil,
+		nil,
+		nil,
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ListProductUUIDsWithPagination lists the product uuids of a catalog with search_after pagination
+// options should be CatalogProductListOptions or url.Values
+func (c *catalogOp) ListProductUUIDsWithPagination(id string, options any) ([]string, Links, error) {
+	sourcePath := path.Join(catalogBasePath, id, "product-uuids")
+	response := new(CatalogProductUUIDsResponse)
+	if err := c.client.GET(
+		sourcePath,
+		options,
+		nil,
+		response,
+	); err != nil {
+		return nil, Links{}, err
+	}
+	return response.Embedded.Items, response.Links, nil
+}
+
+// ListProductsWithPagination lists the products of a catalog with search_after pagination
+// options should be CatalogProductListOptions or url.Values
+func (c *catalogOp) ListProductsWithPagination(id string, options any) ([]Product, Links, error) {
+	sourcePath := path.Join(catalogBasePath, id, "products")
+	response := new(ProductsResponse)
+	if err := c.client.GET(
+		sourcePath,
+		options,
+		nil,
+		response,
+	); err != nil {
+		return nil, Links{}, err
+	}
+	return response.Embedded.Items, response.Links, nil
+}
+
+// GetProduct gets a product of a catalog by uuid
+func (c *catalogOp) GetProduct(id, productUUID string) (*Product, error) {
+	sourcePath := path.Join(catalogBasePath, id, "products", productUUID)
+	product := new(Product)
+	if err := c.client.GET(
+		sourcePath,
+		nil,
+		nil,
+		product,
+	); err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+// ListMappedProductsWithPagination lists the products of a catalog mapped with the product mapping schema,
+// with search_after pagination, options should be CatalogProductListOptions or url.Values
+func (c *catalogOp) ListMappedProductsWithPagination(id string, options any) ([]MappedProduct, Links, error) {
+	sourcePath := path.Join(catalogBasePath, id, "mapped-products")
+	response := new(CatalogMappedProductsResponse)
+	if err := c.client.GET(
+		sourcePath,
+		options,
+		nil,
+		response,
+	); err != nil {
+		return nil, Links{}, err
+	}
+	return response.Embedded.Items, response.Links, nil
+}
+
+// CatalogsResponse is the struct for an akeneo catalogs response
+type CatalogsResponse struct {
+	Links       Links        `json:"_links,omitempty" mapstructure:"_links"`
+	CurrentPage int          `json:"current_page,omitempty" mapstructure:"current_page"`
+	Embedded    catalogItems `json:"_embedded,omitempty" mapstructure:"_embedded"`
+}
+
+type catalogItems struct {
+	Items []Catalog `json:"items,omitempty" mapstructure:"items"`
+}
+
+// CatalogProductUUIDsResponse is the struct for an akeneo catalog product uuids response
+type CatalogProductUUIDsResponse struct {
+	Links    Links                   `json:"_links,omitempty" mapstructure:"_links"`
+	Embedded catalogProductUUIDItems `json:"_embedded,omitempty" mapstructure:"_embedded"`
+}
+
+type catalogProductUUIDItems struct {
+	Items []string `json:"items,omitempty" mapstructure:"items"`
+}
+
+// CatalogMappedProductsResponse is the struct for an akeneo catalog mapped products response
+type CatalogMappedProductsResponse struct {
+	Links    Links                     `json:"_links,omitempty" mapstructure:"_links"`
+	Embedded catalogMappedProductItems `json:"_embedded,omitempty" mapstructure:"_embedded"`
+}
+
+type catalogMappedProductItems struct {
+	Items []MappedProduct `json:"items,omitempty" mapstructure:"items"`
+}
@@ -0,0 +1,182 @@
+package goakeneo
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testAppToken  = "app_token"
+	testCatalogID = "12351d98-200e-4bbc-aa19-7fdda1bd14f2"
+)
+
+// newAppTestClient creates a client authenticated with an app token, as the catalog endpoints require,
+// the handler fails the test when a request is not sent with the app token
+func newAppTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	return newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer "+testAppToken, r.Header.Get("Authorization"))
+		handler(w, r)
+	}), WithAppToken(testAppToken), WithVersion(AkeneoPimVersion7))
+}
+
+func TestCatalogOp_Catalogs(t *testing.T) {
+	var requests []string
+	c := newAppTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == catalogBasePath:
+			assert.Equal(t, "2", r.URL.Query().Get("page"))
+			resp := CatalogsResponse{CurrentPage: 2}
+			resp.Embedded.Items = []Catalog{{ID: testCatalogID, Name: "Store US", Enabled: true}}
+			writeJSON(w, http.StatusOK, resp)
+		case r.Method == http.MethodGet && r.URL.Path == catalogBasePath+"/"+testCatalogID:
+			writeJSON(w, http.StatusOK, Catalog{ID: testCatalogID, Name: "Store US", Enabled: true})
+		case r.Method == http.MethodPost && r.URL.Path == catalogBasePath:
+			var catalog Catalog
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&catalog))
+			assert.Equal(t, Catalog{Name: "Store FR"}, catalog)
+			writeJSON(w, http.StatusCreated, Catalog{ID: "b6a0d9a2-0a49-4b8b-9b27-5c1b6f0f7f10", Name: "Store FR"})
+		case r.Method == http.MethodPatch && r.URL.Path == catalogBasePath+"/"+testCatalogID:
+			var catalog Catalog
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&catalog))
+			assert.Equal(t, Catalog{Name: "Store USA"}, catalog)
+			writeJSON(w, http.StatusOK, Catalog{ID: testCatalogID, Name: "Store USA", Enabled: true})
+		case r.Method == http.MethodDelete && r.URL.Path == catalogBasePath+"/"+testCatalogID:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeJSON(w, http.StatusNotFound, ErrorResponse{Code: http.StatusNotFound, Message: "Catalog \"unknown\" does not exist or you can't access it."})
+		}
+	})
+
+	catalogs, _, err := c.Catalog.ListWithPagination(ListOptions{Page: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []Catalog{{ID: testCatalogID, Name: "Store US", Enabled: true}}, catalogs)
+
+	catalog, err := c.Catalog.GetCatalog(testCatalogID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Store US", catalog.Name)
+
+	created, err := c.Catalog.CreateCatalog("Store FR")
+	assert.NoError(t, err)
+	assert.Equal(t, &Catalog{ID: "b6a0d9a2-0a49-4b8b-9b27-5c1b6f0f7f10", Name: "Store FR"}, created)
+
+	updated, err := c.Catalog.UpdateCatalog(testCatalogID, "Store USA")
+	assert.NoError(t, err)
+	assert.Equal(t, "Store USA", updated.Name)
+
+	assert.NoError(t, c.Catalog.DeleteCatalog(testCatalogID))
+
+	_, err = c.Catalog.GetCatalog("unknown")
+	assert.ErrorContains(t, err, "Catalog \"unknown\" does not exist")
+	assert.Len(t, requests, 6)
+}
+
+func TestCatalogOp_ProductMappingSchema(t *testing.T) {
+	schema := `{"$id":"https://example.com/product","$schema":"https://api.akeneo.com/mapping/product/0.0.2/schema","type":"object","properties":{"uuid":{"type":"string"},"title":{"type":"string"}}}`
+	var stored []byte
+	c := newAppTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, catalogBasePath+"/"+testCatalogID+"/mapping-schemas/product", r.URL.Path)
+		switch r.Method {
+		case http.MethodPut:
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			if !json.Valid(body) || len(body) == 0 || body[0] != '{' {
+				writeJSON(w, http.StatusUnprocessableEntity, ErrorResponse{Code: http.StatusUnprocessableEntity, Message: "You must provide a valid schema."})
+				return
+			}
+			stored = body
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodGet:
+			if stored == nil {
+				writeJSON(w, http.StatusNotFound, ErrorResponse{Code: http.StatusNotFound, Message: "Product mapping schema not found."})
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write(stored)
+		case http.MethodDelete:
+			stored = nil
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	assert.NoError(t, c.Catalog.UpdateProductMappingSchema(testCatalogID, json.RawMessage(schema)))
+	assert.JSONEq(t, schema, string(stored), "the schema is sent as it is")
+	got, err := c.Catalog.GetProductMappingSchema(testCatalogID)
+	assert.NoError(t, err)
+	assert.JSONEq(t, schema, string(got))
+
+	assert.NoError(t, c.Catalog.DeleteProductMappingSchema(testCatalogID))
+	_, err = c.Catalog.GetProductMappingSchema(testCatalogID)
+	assert.ErrorContains(t, err, "Product mapping schema not found.")
+	assert.ErrorContains(t, c.Catalog.UpdateProductMappingSchema(testCatalogID, json.RawMessage(`[]`)), "You must provide a valid schema.")
+}
+
+func TestCatalogOp_Products(t *testing.T) {
+	productUUID := "844c736b-a19b-48a6-a354-6056044729f0"
+	c := newAppTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		base := catalogBasePath + "/" + testCatalogID
+		query := r.URL.Query()
+		switch r.URL.Path {
+		case base + "/product-uuids":
+			assert.Equal(t, "100", query.Get("limit"))
+			assert.Equal(t, "2023-01-01T00:00:00Z", query.Get("updated_after"))
+			resp := CatalogProductUUIDsResponse{}
+			if query.Get("search_after") == "" {
+				resp.Links.Next.Href = "http://" + r.Host + r.URL.Path + "?search_after=" + productUUID + "&limit=100&updated_after=2023-01-01T00:00:00Z"
+				resp.Embedded.Items = []string{productUUID}
+			}
+			writeJSON(w, http.StatusOK, resp)
+		case base + "/products":
+			assert.Equal(t, productUUID, query.Get("search_after"))
+			resp := ProductsResponse{}
+			resp.Embedded.Items = []Product{{UUID: "c7c0f1a4-2c6f-4f53-8d8a-5a8d4b4a6e21", Enabled: true}}
+			writeJSON(w, http.StatusOK, resp)
+		case base + "/products/" + productUUID:
+			writeJSON(w, http.StatusOK, Product{UUID: productUUID, Family: "shirts"})
+		case base + "/mapped-products":
+			assert.Equal(t, "2", query.Get("limit"))
+			resp := CatalogMappedProductsResponse{}
+			resp.Links.Next.Href = "http://" + r.Host + r.URL.Path + "?search_after=" + productUUID
+			resp.Embedded.Items = []MappedProduct{{"uuid": productUUID, "title": "Blue shirt"}}
+			writeJSON(w, http.StatusOK, resp)
+		default:
+			writeJSON(w, http.StatusNotFound, ErrorResponse{Code: http.StatusNotFound, Message: "Product \"unknown\" does not exist or you can't access it."})
+		}
+	})
+
+	uuids, links, err := c.Catalog.ListProductUUIDsWithPagination(testCatalogID, CatalogProductListOptions{Limit: 100, UpdatedAfter: "2023-01-01T00:00:00Z"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{productUUID}, uuids)
+	assert.True(t, links.HasNext())
+	uuids, links, err = c.Catalog.ListProductUUIDsWithPagination(testCatalogID, links.NextOptions())
+	assert.NoError(t, err)
+	assert.Empty(t, uuids)
+	assert.False(t, links.HasNext())
+
+	products, _, err := c.Catalog.ListProductsWithPagination(testCatalogID, CatalogProductListOptions{SearchAfter: productUUID})
+	assert.NoError(t, err)
+	assert.Equal(t, []Product{{UUID: "c7c0f1a4-2c6f-4f53-8d8a-5a8d4b4a6e21", Enabled: true}}, products)
+
+	product, err := c.Catalog.GetProduct(testCatalogID, productUUID)
+	assert.NoError(t, err)
+	assert.Equal(t, &Product{UUID: productUUID, Family: "shirts"}, product)
+	_, err = c.Catalog.GetProduct(testCatalogID, "unknown")
+	assert.ErrorContains(t, err, "Product \"unknown\" does not exist")
+
+	mapped, links, err := c.Catalog.ListMappedProductsWithPagination(testCatalogID, CatalogProductListOptions{Limit: 2})
+	assert.NoError(t, err)
+	assert.True(t, links.HasNext())
+	if assert.Len(t, mapped, 1) {
+		var decoded struct {
+			UUID  string `json:"uuid"`
+			Title string `json:"title"`
+		}
+		assert.NoError(t, mapped[0].Decode(&decoded))
+		assert.Equal(t, "Blue shirt", decoded.Title)
+	}
+}
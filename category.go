@@ -1,15 +1,24 @@
 package goakeneo
 
-import "path"
+import (
+	"net/url"
+	"path"
+)
 
 const (
 	categoryBasePath = "/api/rest/v1/categories"
+	// category media files path since akeneo 7
+	categoryMediaBasePath = "/api/rest/v1/category-media-files"
 )
 
 // CategoryService is an interface for interacting with the Akeneo Category API.
 type CategoryService interface {
 	ListWithPagination(options any) ([]Category, Links, error)
 	Get(code string) (*Category, error)
+	Create(category Category) error
+	Update(code string, category Category) error
+	UpsertCategories(categories []Category) (PatchProductResponse, error)
+	DownloadMediaFile(code, filePath string) error
 }
 
 type categoryOp struct {
@@ -41,6 +50,49 @@ func (c *categoryOp) Get(code string) (*Category, error) {
 	return category, nil
 }
 
+// Create creates a category
+func (c *categoryOp) Create(category Category) error {
+	if err := c.client.POST(
+		categoryBasePath,
+		nil,
+		category,
+		nil,
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Update updates a category by code, the category is created if it does not exist
+func (c *categoryOp) Update(code string, category Category) error {
+	sourcePath := path.Join(categoryBasePath, code)
+	if err := c.client.PATCH(
+		sourcePath,
+		nil,
+		category,
+		nil,
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpsertCategories updates or creates several categories at once, returns the status of every category
+func (c *categoryOp) UpsertCategories(categories []Category) (PatchProductResponse, error) {
+	return c.client.patchCollection(categoryBasePath, categories)
+}
+
+// DownloadMediaFile downloads a category media file by code, i.e. CategoryImage.FilePath
+func (c *categoryOp) DownloadMediaFile(code, filePath string) error {
+	sourcePath := path.Join(categoryMediaBasePath, code, "download")
+	sourceP, _ := url.Parse(sourcePath)
+	downloadURL := c.client.baseURL.ResolveReference(sourceP).String()
+	if err := c.client.download(downloadURL, filePath); err != nil {
+		return err
+	}
+	return nil
+}
+
 // CategoriesResponse is the struct for a akeneo categories response
 type CategoriesResponse struct {
 	Links       Links         `json:"_links,omitempty" mapstructure:"_links"`
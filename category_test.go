@@ -1,6 +1,11 @@
 package goakeneo
 
 import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -18,3 +23,148 @@ func TestCategory(t *testing.T) {
 		assert.NotNil(t, category)
 	}
 }
+
+func TestCategory_Values(t *testing.T) {
+	raw := `{
+		"code": "winter",
+		"values": {
+			"description|96b88bf4-c2b7-4b64-a1f9-5d4876c02c26|ecommerce|en_US": {
+				"data": "<p>Winter collection</p>",
+				"type": "richtext",
+				"channel": "ecommerce",
+				"locale": "en_US",
+				"attribute_code": "description|96b88bf4-c2b7-4b64-a1f9-5d4876c02c26"
+			},
+			"banner|8587cda6-58c8-47fa-9278-033e1d8c735c": {
+				"data": {
+					"size": 168107,
+					"extension": "jpg",
+					"file_path": "8/8/3/d/883d041fc9f22ce42fee07d96c05b0b7ec7e66de_shoes.jpg",
+					"mime_type": "image/jpeg",
+					"original_filename": "shoes.jpg"
+				},
+				"type": "image",
+				"channel": null,
+				"locale": null,
+				"attribute_code": "banner|8587cda6-58c8-47fa-9278-033e1d8c735c",
+				"_links": {"download": {"href": "https://demo.akeneo.com/api/rest/v1/category-media-files/8/8/3/d/883d041fc9f22ce42fee07d96c05b0b7ec7e66de_shoes.jpg/download"}}
+			}
+		}
+	}`
+	var category Category
+	assert.NoError(t, json.Unmarshal([]byte(raw), &category))
+
+	description, ok := category.Value("description", "ecommerce", "en_US")
+	assert.True(t, ok)
+	assert.Equal(t, "96b88bf4-c2b7-4b64-a1f9-5d4876c02c26", description.UUID())
+	assert.Equal(t, "description|96b88bf4-c2b7-4b64-a1f9-5d4876c02c26|ecommerce|en_US", description.Key())
+	text, ok := description.Text()
+	assert.True(t, ok)
+	assert.Equal(t, "<p>Winter collection</p>", text)
+
+	banner, ok := category.Value("banner", "", "")
+	assert.True(t, ok)
+	_, ok = banner.Text()
+	assert.False(t, ok)
+	image, err := banner.Image()
+	assert.NoError(t, err)
+	assert.Equal(t, 168107, image.Size)
+	assert.Equal(t, "8/8/3/d/883d041fc9f22ce42fee07d96c05b0b7ec7e66de_shoes.jpg", image.FilePath)
+	assert.NotEmpty(t, banner.DownloadURL())
+}
+
+func TestCategoryOp_UpsertCategories(t *testing.T) {
+	master := "master"
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, categoryBasePath, r.URL.Path)
+		assert.Equal(t, []Category{{Code: "master"}, {Code: "winter", Parent: &master}}, readCollection[Category](t, r))
+		writeCollection(w, PatchProductResponse{
+			{Line: 1, Code: "master", StatusCode: http.StatusNoContent},
+			{Line: 2, Code: "winter", StatusCode: http.StatusUnprocessableEntity, Message: "Validation failed."},
+		})
+	}), WithVersion(AkeneoPimVersion6))
+	lines, err := c.Category.UpsertCategories([]Category{{Code: "master"}, {Code: "winter", Parent: &master}})
+	assert.NoError(t, err)
+	assert.Equal(t, PatchProductResponse{
+		{Line: 1, Code: "master", StatusCode: http.StatusNoContent},
+		{Line: 2, Code: "winter", StatusCode: http.StatusUnprocessableEntity, Message: "Validation failed."},
+	}, lines)
+}
+
+func TestCategoryOp_Create(t *testing.T) {
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, categoryBasePath, r.URL.Path)
+		var category Category
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&category))
+		if category.Code == "master" {
+			writeJSON(w, http.StatusUnprocessableEntity, ErrorResponse{
+				Code:    http.StatusUnprocessableEntity,
+				Message: "Validation failed.",
+				Errors:  []ValidationError{{Property: "code", Message: "This value is already used."}},
+			})
+			return
+		}
+		assert.Equal(t, "master", *category.Parent)
+		w.WriteHeader(http.StatusCreated)
+	}), WithVersion(AkeneoPimVersion6))
+	master := "master"
+	assert.NoError(t, c.Category.Create(Category{Code: "winter", Parent: &master}))
+	assert.ErrorContains(t, c.Category.Create(Category{Code: "master"}), "This value is already used.")
+}
+
+func TestCategoryOp_Update(t *testing.T) {
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, categoryBasePath+"/winter", r.URL.Path)
+		assert.Equal(t, defaultContentType, r.Header.Get("Content-Type"))
+		var category Category
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&category))
+		assert.Equal(t, "Winter", category.Labels["en_US"])
+		w.WriteHeader(http.StatusNoContent)
+	}), WithVersion(AkeneoPimVersion6))
+	assert.NoError(t, c.Category.Update("winter", Category{Code: "winter", Labels: map[string]string{"en_US": "Winter"}}))
+}
+
+func TestCategoryOp_UpsertCategoriesError(t *testing.T) {
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusRequestEntityTooLarge, ErrorResponse{Code: http.StatusRequestEntityTooLarge, Message: "Too many resources to process, 100 is the maximum allowed."})
+	}), WithVersion(AkeneoPimVersion6))
+	lines, err := c.Category.UpsertCategories(make([]Category, 101))
+	assert.ErrorContains(t, err, "Too many resources to process")
+	assert.Nil(t, lines)
+}
+
+func TestCategoryOp_DownloadMediaFile(t *testing.T) {
+	code := "8/8/3/d/883d041fc9f22ce42fee07d96c05b0b7ec7e66de_shoes.jpg"
+	content := bytes.Repeat([]byte("jpeg"), 64*1024)
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		switch r.URL.Path {
+		case categoryMediaBasePath + "/" + code + "/download":
+			w.Header().Set("Content-Type", "image/jpeg")
+			_, _ = w.Write(content)
+		case categoryMediaBasePath + "/forbidden.jpg/download":
+			writeJSON(w, http.StatusForbidden, ErrorResponse{Code: http.StatusForbidden, Message: "You are not allowed to access the category media files."})
+		default:
+			writeJSON(w, http.StatusNotFound, ErrorResponse{Code: http.StatusNotFound, Message: "Resource not found"})
+		}
+	}), WithVersion(AkeneoPimVersion7))
+
+	dir := t.TempDir()
+	target := filepath.Join(dir, "categories", "shoes.jpg")
+	assert.NoError(t, c.Category.DownloadMediaFile(code, target))
+	downloaded, err := os.ReadFile(target)
+	assert.NoError(t, err)
+	assert.Equal(t, content, downloaded)
+
+	err = c.Category.DownloadMediaFile("forbidden.jpg", filepath.Join(dir, "forbidden.jpg"))
+	assert.ErrorContains(t, err, "error Code: 403")
+	assert.ErrorContains(t, err, "You are not allowed to access the category media files.")
+	assert.NoFileExists(t, filepath.Join(dir, "forbidden.jpg"))
+
+	err = c.Category.DownloadMediaFile("unknown.jpg", filepath.Join(dir, "unknown.jpg"))
+	assert.ErrorContains(t, err, "file not found")
+	assert.NoFileExists(t, filepath.Join(dir, "unknown.jpg"))
+}
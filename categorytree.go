@@ -0,0 +1,163 @@
+package goakeneo
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// CategoryTree is an in-memory tree of akeneo categories built from Category.Parent
+type CategoryTree struct {
+	roots []*CategoryNode
+	nodes map[string]*CategoryNode
+}
+
+// CategoryNode is a node of the CategoryTree
+type CategoryNode struct {
+	Category Category
+	Parent   *CategoryNode
+	Children []*CategoryNode
+}
+
+// LoadCategoryTree loads all categories with their positions and builds the tree
+func LoadCategoryTree(service CategoryService) (*CategoryTree, error) {
+	options := CategoryListOptions{
+		WithPosition: true,
+	}
+	options.Limit = 100
+	var categories []Category
+	items, links, err := service.ListWithPagination(options)
+	for {
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to load categories")
+		}
+		categories = append(categories, items...)
+		if !links.HasNext() {
+			break
+		}
+		items, links, err = service.ListWithPagination(links.NextOptions())
+	}
+	return NewCategoryTree(categories), nil
+}
+
+// NewCategoryTree builds a category tree from a flat list of categories
+// categories whose parent is unknown are considered as roots
+// children are ordered by position, then by code
+func NewCategoryTree(categories []Category) *CategoryTree {
+	t := &CategoryTree{
+		nodes: make(map[string]*CategoryNode, len(categories)),
+	}
+	for _, category := range categories {
+		t.nodes[category.Code] = &CategoryNode{Category: category}
+	}
+	for _, category := range categories {
+		node := t.nodes[category.Code]
+		if category.Parent == nil || *category.Parent == "" {
+			t.roots = append(t.roots, node)
+			continue
+		}
+		parent, ok := t.nodes[*category.Parent]
+		if !ok {
+			t.roots = append(t.roots, node)
+			continue
+		}
+		node.Parent = parent
+		parent.Children = append(parent.Children, node)
+	}
+	sortCategoryNodes(t.roots)
+	for _, node := range t.nodes {
+		sortCategoryNodes(node.Children)
+	}
+	return t
+}
+
+func sortCategoryNodes(nodes []*CategoryNode) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		pi, pj := nodes[i].Category.Position, nodes[j].Category.Position
+		switch {
+		case pi != nil && pj != nil && *pi != *pj:
+			return *pi < *pj
+		case pi != nil && pj == nil:
+			return true
+		case pi == nil && pj != nil:
+			return false
+		}
+		return nodes[i].Category.Code < nodes[j].Category.Code
+	})
+}
+
+// Roots returns the root nodes of the tree
+func (t *CategoryTree) Roots() []*CategoryNode {
+	return t.roots
+}
+
+// Node returns the node of a category by code
+func (t *CategoryTree) Node(code string) (*CategoryNode, bool) {
+	node, ok := t.nodes[code]
+	return node, ok
+}
+
+// PathOf returns the category codes from the root to the category, the category included
+// returns nil if the category is unknown
+func (t *CategoryTree) PathOf(code string) []string {
+	node, ok := t.nodes[code]
+	if !ok {
+		return nil
+	}
+	var codes []string
+	for n := node; n != nil; n = n.Parent {
+		codes = append([]string{n.Category.Code}, codes...)
+	}
+	return codes
+}
+
+// Descendants returns all the descendants of a category, depth first and ordered
+func (t *CategoryTree) Descendants(code string) []Category {
+	node, ok := t.nodes[code]
+	if !ok {
+		return nil
+	}
+	var result []Category
+	var walk func(n *CategoryNode)
+	walk = func(n *CategoryNode) {
+		for _, child := range n.Children {
+			result = append(result, child.Category)
+			walk(child)
+		}
+	}
+	walk(node)
+	return result
+}
+
+// Label returns the label of a category for the locale
+// falls back to the category code wrapped in brackets like the PIM does
+func (t *CategoryTree) Label(code, locale string) string {
+	node, ok := t.nodes[code]
+	if !ok {
+		return ""
+	}
+	return node.Label(locale)
+}
+
+// PathLabels returns the labels from the root to the category for the locale
+func (t *CategoryTree) PathLabels(code, locale string) []string {
+	codes := t.PathOf(code)
+	labels := make([]string, len(codes))
+	for i, c := range codes {
+		labels[i] = t.Label(c, locale)
+	}
+	return labels
+}
+
+// Label returns the label of the node for the locale
+func (n *CategoryNode) Label(locale string) string {
+	if label, ok := n.Category.Labels[locale]; ok && label != "" {
+		return label
+	}
+	return "[" + n.Category.Code + "]"
+}
+
+// IsRoot returns true if the node is a root of the tree
+func (n *CategoryNode) IsRoot() bool {
+	return n.Parent == nil
+}
@@ -0,0 +1,82 @@
+package goakeneo
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCategoryTree(t *testing.T) {
+	master, clothes, shoes, shirts := "master", "clothes", "shoes", "shirts"
+	one, two := 1, 2
+	tree := NewCategoryTree([]Category{
+		{Code: shirts, Parent: &clothes},
+		{Code: shoes, Parent: &master, Position: &two},
+		{Code: clothes, Parent: &master, Position: &one, Labels: map[string]string{"en_US": "Clothes"}},
+		{Code: master},
+	})
+	assert.Len(t, tree.Roots(), 1)
+	assert.Equal(t, []string{"master", "clothes", "shirts"}, tree.PathOf(shirts))
+	assert.Nil(t, tree.PathOf("unknown"))
+
+	descendants := tree.Descendants(master)
+	codes := make([]string, len(descendants))
+	for i, d := range descendants {
+		codes[i] = d.Code
+	}
+	assert.Equal(t, []string{"clothes", "shirts", "shoes"}, codes)
+
+	assert.Equal(t, "Clothes", tree.Label(clothes, "en_US"))
+	assert.Equal(t, "[clothes]", tree.Label(clothes, "fr_FR"))
+	assert.Equal(t, []string{"[master]", "Clothes", "[shirts]"}, tree.PathLabels(shirts, "en_US"))
+}
+
+func TestLoadCategoryTree(t *testing.T) {
+	var pages []string
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, categoryBasePath, r.URL.Path)
+		query := r.URL.Query()
+		assert.Equal(t, "true", query.Get("with_position"))
+		assert.Equal(t, "100", query.Get("limit"))
+		pages = append(pages, query.Get("page"))
+		master, one, two := "master", 1, 2
+		resp := CategoriesResponse{}
+		switch query.Get("page") {
+		case "":
+			resp.Links.Next.Href = "http://" + r.Host + r.URL.Path + "?page=2&limit=100&with_position=true"
+			resp.Embedded.Items = []Category{{Code: "master"}, {Code: "shoes", Parent: &master, Position: &two}}
+		case "2":
+			resp.Embedded.Items = []Category{{Code: "clothes", Parent: &master, Position: &one}}
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}), WithVersion(AkeneoPimVersion6))
+
+	tree, err := LoadCategoryTree(c.Category)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"", "2"}, pages)
+	if assert.Len(t, tree.Roots(), 1) {
+		var children []string
+		for _, child := range tree.Roots()[0].Children {
+			children = append(children, child.Category.Code)
+		}
+		assert.Equal(t, []string{"clothes", "shoes"}, children, "the children are ordered by position")
+	}
+	assert.Equal(t, []string{"master", "shoes"}, tree.PathOf("shoes"))
+}
+
+func TestLoadCategoryTree_Error(t *testing.T) {
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "" {
+			resp := CategoriesResponse{}
+			resp.Links.Next.Href = "http://" + r.Host + r.URL.Path + "?page=2"
+			resp.Embedded.Items = []Category{{Code: "master"}}
+			writeJSON(w, http.StatusOK, resp)
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Code: http.StatusInternalServerError, Message: "Internal error"})
+	}), WithVersion(AkeneoPimVersion6))
+	tree, err := LoadCategoryTree(c.Category)
+	assert.ErrorContains(t, err, "unable to load categories")
+	assert.Nil(t, tree)
+}
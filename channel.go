@@ -1,5 +1,7 @@
 package goakeneo
 
+import "path"
+
 const (
 	channelBasePath = "/api/rest/v1/channels"
 )
@@ -7,6 +9,10 @@ const (
 // ChannelService is the interface to interact with the Akeneo Channel API
 type ChannelService interface {
 	ListWithPagination(options any) ([]Channel, Links, error)
+	GetChannel(code string) (*Channel, error)
+	CreateChannel(channel Channel) error
+	UpdateChannel(code string, channel Channel) error
+	UpsertChannels(channels []Channel) (PatchProductResponse, error)
 }
 
 type channelOp struct {
@@ -28,6 +34,53 @@ func (c *channelOp) ListWithPagination(options any) ([]Channel, Links, error) {
 	return channelResponse.Embedded.Items, channelResponse.Links, nil
 }
 
+// GetChannel gets a channel by code
+func (c *channelOp) GetChannel(code string) (*Channel, error) {
+	sourcePath := path.Join(channelBasePath, code)
+	channel := new(Channel)
+	if err := c.client.GET(
+		sourcePath,
+		nil,
+		nil,
+		channel,
+	); err != nil {
+		return nil, err
+	}
+	return channel, nil
+}
+
+// CreateChannel creates a channel
+func (c *channelOp) CreateChannel(channel Channel) error {
+	if err := c.client.POST(
+		channelBasePath,
+		nil,
+		channel,
+		nil,
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateChannel updates a channel by code, the channel is created if it does not exist
+func (c *channelOp) UpdateChannel(code string, channel Channel) error {
+	sourcePath := path.Join(channelBasePath, code)
+	if err := c.client.PATCH(
+		sourcePath,
+		nil,
+		channel,
+		nil,
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpsertChannels updates or creates several channels at once, returns the status of every channel
+func (c *channelOp) UpsertChannels(channels []Channel) (PatchProductResponse, error) {
+	return c.client.patchCollection(channelBasePath, channels)
+}
+
 // ChannelsResponse is the struct for an akeneo channels response
 type ChannelsResponse struct {
 	Links       Links        `json:"_links" mapstructure:"_links"`
@@ -0,0 +1,67 @@
+package goakeneo
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChannelOp_GetChannel(t *testing.T) {
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		if r.URL.Path != channelBasePath+"/ecommerce" {
+			writeJSON(w, http.StatusNotFound, ErrorResponse{Code: http.StatusNotFound, Message: "Resource not found"})
+			return
+		}
+		writeJSON(w, http.StatusOK, Channel{Code: "ecommerce", Locales: []string{"en_US"}, Currencies: []string{"EUR"}})
+	}), WithVersion(AkeneoPimVersion6))
+	channel, err := c.Channel.GetChannel("ecommerce")
+	assert.NoError(t, err)
+	assert.Equal(t, &Channel{Code: "ecommerce", Locales: []string{"en_US"}, Currencies: []string{"EUR"}}, channel)
+	_, err = c.Channel.GetChannel("print")
+	assert.ErrorContains(t, err, "Resource not found")
+}
+
+func TestChannelOp_CreateChannel(t *testing.T) {
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, channelBasePath, r.URL.Path)
+		var channel Channel
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&channel))
+		assert.Equal(t, Channel{Code: "print", CategoryTree: "master"}, channel)
+		w.WriteHeader(http.StatusCreated)
+	}), WithVersion(AkeneoPimVersion6))
+	assert.NoError(t, c.Channel.CreateChannel(Channel{Code: "print", CategoryTree: "master"}))
+}
+
+func TestChannelOp_UpdateChannel(t *testing.T) {
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, channelBasePath+"/print", r.URL.Path)
+		var channel Channel
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&channel))
+		assert.Equal(t, Channel{Code: "print", Locales: []string{"fr_FR"}}, channel)
+		w.WriteHeader(http.StatusNoContent)
+	}), WithVersion(AkeneoPimVersion6))
+	assert.NoError(t, c.Channel.UpdateChannel("print", Channel{Code: "print", Locales: []string{"fr_FR"}}))
+}
+
+func TestChannelOp_UpsertChannels(t *testing.T) {
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, channelBasePath, r.URL.Path)
+		assert.Equal(t, []Channel{{Code: "print"}, {Code: "mobile"}}, readCollection[Channel](t, r))
+		writeCollection(w, PatchProductResponse{
+			{Line: 1, Code: "print", StatusCode: http.StatusNoContent},
+			{Line: 2, Code: "mobile", StatusCode: http.StatusUnprocessableEntity, Message: "Validation failed."},
+		})
+	}), WithVersion(AkeneoPimVersion6))
+	lines, err := c.Channel.UpsertChannels([]Channel{{Code: "print"}, {Code: "mobile"}})
+	assert.NoError(t, err)
+	assert.Equal(t, PatchProductResponse{
+		{Line: 1, Code: "print", StatusCode: http.StatusNoContent},
+		{Line: 2, Code: "mobile", StatusCode: http.StatusUnprocessableEntity, Message: "Validation failed."},
+	}, lines)
+}
@@ -0,0 +1,57 @@
+package goakeneo
+
+import "path"
+
+const (
+	currencyBasePath = "/api/rest/v1/currencies"
+)
+
+// CurrencyService is the interface to interact with the Akeneo Currency API
+type CurrencyService interface {
+	ListWithPagination(options any) ([]Currency, Links, error)
+	GetCurrency(code string) (*Currency, error)
+}
+
+type currencyOp struct {
+	client *Client
+}
+
+// ListWithPagination lists currencies with pagination
+func (c *currencyOp) ListWithPagination(options any) ([]Currency, Links, error) {
+	currencyResponse := new(CurrenciesResponse)
+	if err := c.client.GET(
+		currencyBasePath,
+		options,
+		nil,
+		currencyResponse,
+	); err != nil {
+		return nil, Links{}, err
+	}
+	return currencyResponse.Embedded.Items, currencyResponse.Links, nil
+}
+
+// GetCurrency gets a currency by code
+func (c *currencyOp) GetCurrency(code string) (*Currency, error) {
+	sourcePath := path.Join(currencyBasePath, code)
+	currency := new(Currency)
+	if err := c.client.GET(
+		sourcePath,
+		nil,
+		nil,
+		currency,
+	); err != nil {
+		return nil, err
+	}
+	return currency, nil
+}
+
+// CurrenciesResponse is the struct for an akeneo currencies response
+type CurrenciesResponse struct {
+	Links       Links         `json:"_links" mapstructure:"_links"`
+	CurrentPage int           `json:"current_page" mapstructure:"current_page"`
+	Embedded    currencyItems `json:"_embedded" mapstructure:"_embedded"`
+}
+
+type currencyItems struct {
+	Items []Currency `json:"items" mapstructure:"items"`
+}
@@ -0,0 +1,55 @@
+package goakeneo
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCurrencyOp_ListWithPagination(t *testing.T) {
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, currencyBasePath, r.URL.Path)
+		resp := CurrenciesResponse{CurrentPage: 1}
+		if r.URL.Query().Get("page") == "" {
+			assert.Equal(t, `{"enabled":[{"operator":"=","value":true}]}`, r.URL.Query().Get("search"))
+			resp.Links.Next.Href = "http://" + r.Host + r.URL.Path + "?page=2"
+			resp.Embedded.Items = []Currency{{Code: "EUR", Enabled: true}}
+		} else {
+			resp.CurrentPage = 2
+			resp.Embedded.Items = []Currency{{Code: "USD", Enabled: true}}
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}), WithVersion(AkeneoPimVersion6))
+
+	currencies, links, err := c.Currency.ListWithPagination(url.Values{"search": {`{"enabled":[{"operator":"=","value":true}]}`}})
+	assert.NoError(t, err)
+	assert.Equal(t, []Currency{{Code: "EUR", Enabled: true}}, currencies)
+	assert.True(t, links.HasNext())
+
+	currencies, links, err = c.Currency.ListWithPagination(links.NextOptions())
+	assert.NoError(t, err)
+	assert.Equal(t, []Currency{{Code: "USD", Enabled: true}}, currencies)
+	assert.False(t, links.HasNext())
+}
+
+func TestCurrencyOp_GetCurrency(t *testing.T) {
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		switch r.URL.Path {
+		case currencyBasePath + "/EUR":
+			writeJSON(w, http.StatusOK, Currency{Code: "EUR", Enabled: true, Label: "Euro"})
+		default:
+			writeJSON(w, http.StatusNotFound, ErrorResponse{Code: http.StatusNotFound, Message: "Resource `XXX` does not exist."})
+		}
+	}), WithVersion(AkeneoPimVersion7))
+
+	currency, err := c.Currency.GetCurrency("EUR")
+	assert.NoError(t, err)
+	assert.Equal(t, &Currency{Code: "EUR", Enabled: true, Label: "Euro"}, currency)
+
+	_, err = c.Currency.GetCurrency("XXX")
+	assert.ErrorContains(t, err, "Resource `XXX` does not exist.")
+}
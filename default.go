@@ -6,6 +6,7 @@ const (
 	defaultHTTPTimeout       = 10 * time.Second
 	defaultAccept            = "application/json"
 	defaultContentType       = "application/json"
+	defaultCollectionType    = "application/vnd.akeneo.collection+json" // bulk PATCH requests and responses, one json object per line
 	defaultUploadContentType = "multipart/form-data"
 	defaultUserAgent         = "go-akeneo v1.0.0"
 	defaultRateLimit         = 5 // 5 requests per second
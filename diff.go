@@ -0,0 +1,338 @@
+package goakeneo
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Product fields reported by Diff
+const (
+	ProductFieldEnabled                = "enabled"
+	ProductFieldFamily                 = "family"
+	ProductFieldParent                 = "parent"
+	ProductFieldCategories             = "categories"
+	ProductFieldGroups                 = "groups"
+	ProductFieldValues                 = "values"
+	ProductFieldAssociations           = "associations"
+	ProductFieldQuantifiedAssociations = "quantified_associations"
+)
+
+// ProductChange is a change between two versions of a product
+type ProductChange struct {
+	Field     string // one of the ProductField constants
+	Attribute string // the attribute of a value, or the association type of an association
+	Locale    string // values only
+	Scope     string // values only
+	Old       any    // nil when added
+	New       any    // nil when removed
+}
+
+// String returns the change as a string
+func (c ProductChange) String() string {
+	name := c.Field
+	if c.Attribute != "" {
+		name += "." + c.Attribute
+	}
+	if c.Locale != "" || c.Scope != "" {
+		name += fmt.Sprintf("[%s|%s]", c.Locale, c.Scope)
+	}
+	return fmt.Sprintf("%s: %v -> %v", name, c.Old, c.New)
+}
+
+// Diff returns the changes from old to new, in the order of the product fields then by attribute, locale and scope.
+// the read only fields, i.e. created, updated, quality scores or links, are ignored.
+// the categories, the groups and the codes of the associations and quantified associations are compared as sets.
+// the values are compared after the attribute types of the schema: the options of the multi-select attributes
+// and the prices are sets, the numbers and the amounts of the metrics and prices are compared as numbers,
+// i.e. "10.5000" as returned by the PIM equals 10.5. the other values are compared as they are and their lists keep
+// their order, i.e. the asset and reference entity collections or the text that looks like a number.
+// the values of the attributes missing from the schema, or of every attribute when schema is nil, are compared as they are
+func Diff(old, new Product, schema *Schema) []ProductChange {
+	var changes []ProductChange
+	if old.Enabled != new.Enabled {
+		changes = append(changes, ProductChange{Field: ProductFieldEnabled, Old: old.Enabled, New: new.Enabled})
+	}
+	if old.Family != new.Family {
+		changes = append(changes, ProductChange{Field: ProductFieldFamily, Old: old.Family, New: new.Family})
+	}
+	if old.Parent != new.Parent {
+		changes = append(changes, ProductChange{Field: ProductFieldParent, Old: old.Parent, New: new.Parent})
+	}
+	if !sameStringSet(old.Categories, new.Categories) {
+		changes = append(changes, ProductChange{Field: ProductFieldCategories, Old: old.Categories, New: new.Categories})
+	}
+	if !sameStringSet(old.Groups, new.Groups) {
+		changes = append(changes, ProductChange{Field: ProductFieldGroups, Old: old.Groups, New: new.Groups})
+	}
+	changes = append(changes, diffValues(old.Values, new.Values, schema)...)
+	for _, code := range unionKeys(old.Associations, new.Associations) {
+		o, n := old.Associations[code], new.Associations[code]
+		if !sameStringSet(o.Groups, n.Groups) || !sameStringSet(o.Products, n.Products) || !sameStringSet(o.ProductModels, n.ProductModels) {
+			changes = append(changes, ProductChange{Field: ProductFieldAssociations, Attribute: code, Old: o, New: n})
+		}
+	}
+	for _, code := range unionKeys(old.QuantifiedAssociations, new.QuantifiedAssociations) {
+		o, n := old.QuantifiedAssociations[code], new.QuantifiedAssociations[code]
+		if !sameQuantities(o, n) {
+			changes = append(changes, ProductChange{Field: ProductFieldQuantifiedAssociations, Attribute: code, Old: o, New: n})
+		}
+	}
+	return changes
+}
+
+// diffValues compares the values per attribute, locale and scope
+func diffValues(old, new map[string][]ProductValue, schema *Schema) []ProductChange {
+	var changes []ProductChange
+	for _, code := range unionKeys(old, new) {
+		var attribute *Attribute
+		if schema != nil {
+			attribute, _ = schema.AttributeByCode(code)
+		}
+		oldByKey, newByKey := valuesByKey(old[code]), valuesByKey(new[code])
+		keys := make([]string, 0, len(oldByKey)+len(newByKey))
+		for key := range oldByKey {
+			keys = append(keys, key)
+		}
+		for key := range newByKey {
+			if _, ok := oldByKey[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			o, inOld := oldByKey[key]
+			n, inNew := newByKey[key]
+			var oldData, newData any
+			if inOld {
+				oldData = o.Data
+			}
+			if inNew {
+				newData = n.Data
+			}
+			if !inNew && oldData == nil {
+				continue
+			}
+			if reflect.DeepEqual(comparableData(attribute, oldData), comparableData(attribute, newData)) {
+				continue
+			}
+			locale, scope, _ := strings.Cut(key, "|")
+			changes = append(changes, ProductChange{
+				Field:     ProductFieldValues,
+				Attribute: code,
+				Locale:    locale,
+				Scope:     scope,
+				Old:       oldData,
+				New:       newData,
+			})
+		}
+	}
+	return changes
+}
+
+// ProductPatch is a partial product, only the fields it holds are updated, see UpsertProductPatches.
+// unlike a Product, whose empty fields are omitted, it holds false, null and empty lists,
+// i.e. to disable a product, to remove its family or its parent, or to empty its categories
+type ProductPatch map[string]any
+
+// MinimalPatch returns a patch holding only the changes from old to new, see Diff, with the identifier and uuid
+// of the product, to send with UpsertProductPatches. the removed values are sent with a null data,
+// the removed family and parent with null and the emptied lists with [].
+// new must be the complete product: Enabled is a plain bool, so a product decoded without "enabled" is disabled
+func MinimalPatch(old, new Product, schema *Schema) ProductPatch {
+	patch := ProductPatch{}
+	if uuid := firstNonEmpty(new.UUID, old.UUID); uuid != "" {
+		patch["uuid"] = uuid
+	}
+	if identifier := firstNonEmpty(new.Identifier, old.Identifier); identifier != "" {
+		patch["identifier"] = identifier
+	}
+	values := make(map[string][]ProductValue)
+	associations := make(map[string]map[string][]string)
+	quantifiedAssociations := make(map[string]map[string]any)
+	for _, change := range Diff(old, new, schema) {
+		switch change.Field {
+		case ProductFieldEnabled:
+			patch[ProductFieldEnabled] = new.Enabled
+		case ProductFieldFamily:
+			patch[ProductFieldFamily] = nilIfEmpty(new.Family)
+		case ProductFieldParent:
+			patch[ProductFieldParent] = nilIfEmpty(new.Parent)
+		case ProductFieldCategories:
+			patch[ProductFieldCategories] = nonNilStrings(new.Categories)
+		case ProductFieldGroups:
+			patch[ProductFieldGroups] = nonNilStrings(new.Groups)
+		case ProductFieldValues:
+			values[change.Attribute] = append(values[change.Attribute], ProductValue{
+				Locale: nilIfEmpty(change.Locale),
+				Scope:  nilIfEmpty(change.Scope),
+				Data:   change.New,
+			})
+		case ProductFieldAssociations:
+			n := new.Associations[change.Attribute]
+			associations[change.Attribute] = map[string][]string{
+				"groups":         nonNilStrings(n.Groups),
+				"products":       nonNilStrings(n.Products),
+				"product_models": nonNilStrings(n.ProductModels),
+			}
+		case ProductFieldQuantifiedAssociations:
+			n := new.QuantifiedAssociations[change.Attribute]
+			products, productModels := n.Products, n.ProductModels
+			if products == nil {
+				products = []productQuantity{}
+			}
+			if productModels == nil {
+				productModels = []productModelQuantity{}
+			}
+			quantifiedAssociations[change.Attribute] = map[string]any{
+				"products":       products,
+				"product_models": productModels,
+			}
+		}
+	}
+	if len(values) > 0 {
+		patch[ProductFieldValues] = values
+	}
+	if len(associations) > 0 {
+		patch[ProductFieldAssociations] = associations
+	}
+	if len(quantifiedAssociations) > 0 {
+		patch[ProductFieldQuantifiedAssociations] = quantifiedAssociations
+	}
+	return patch
+}
+
+// valuesByKey indexes the values by "locale|scope"
+func valuesByKey(values []ProductValue) map[string]ProductValue {
+	byKey := make(map[string]ProductValue, len(values))
+	for _, v := range values {
+		byKey[stringOf(v.Locale)+"|"+stringOf(v.Scope)] = v
+	}
+	return byKey
+}
+
+// comparableData returns the data of a value as decoded from json and normalized after the type of its attribute:
+// the options of a multi-select sorted, the prices sorted by currency, and the numbers and amounts as canonical numbers,
+// so that "12.5000" equals 12.5 and "12.0000" equals 12. the data of the other attributes is returned as it is
+func comparableData(attribute *Attribute, data any) any {
+	data = normalizeData(data)
+	if attribute == nil {
+		return data
+	}
+	switch attribute.Type {
+	case AttributeTypeMultiSelect, AttributeTypeReferenceDataMultiSelect:
+		if codes, ok := stringsOf(data); ok {
+			sorted := append([]string(nil), codes...)
+			sort.Strings(sorted)
+			return sorted
+		}
+	case AttributeTypeNumber:
+		return comparableNumber(data)
+	case AttributeTypeMetric:
+		if metric, ok := data.(map[string]any); ok {
+			metric["amount"] = comparableNumber(metric["amount"])
+		}
+	case AttributeTypePrice:
+		if prices, ok := data.([]any); ok {
+			for _, price := range prices {
+				if p, ok := price.(map[string]any); ok {
+					p["amount"] = comparableNumber(p["amount"])
+				}
+			}
+			sort.SliceStable(prices, func(i, j int) bool {
+				return currencyOf(prices[i]) < currencyOf(prices[j])
+			})
+		}
+	}
+	return data
+}
+
+// comparableNumber returns a number as a canonical rational string, or the data when it is not a number
+func comparableNumber(data any) any {
+	if r, ok := ratOf(data); ok {
+		return r.RatString()
+	}
+	return data
+}
+
+func currencyOf(price any) string {
+	p, _ := price.(map[string]any)
+	currency, _ := p["currency"].(string)
+	return currency
+}
+
+// sameQuantities returns true when two quantified associations hold the same products and product models
+// with the same quantities, in any order
+func sameQuantities(a, b quantifiedAssociation) bool {
+	if len(a.Products) != len(b.Products) || len(a.ProductModels) != len(b.ProductModels) {
+		return false
+	}
+	products := func(q quantifiedAssociation) []productQuantity {
+		sorted := append([]productQuantity(nil), q.Products...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i].Identifier < sorted[j].Identifier })
+		return sorted
+	}
+	productModels := func(q quantifiedAssociation) []productModelQuantity {
+		sorted := append([]productModelQuantity(nil), q.ProductModels...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i].Code < sorted[j].Code })
+		return sorted
+	}
+	return reflect.DeepEqual(products(a), products(b)) && reflect.DeepEqual(productModels(a), productModels(b))
+}
+
+func sameStringSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[string]int, len(a))
+	for _, s := range a {
+		counts[s]++
+	}
+	for _, s := range b {
+		if counts[s] == 0 {
+			return false
+		}
+		counts[s]--
+	}
+	return true
+}
+
+// unionKeys returns the sorted keys of two maps
+func unionKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// nonNilStrings returns an empty list instead of nil, so that the list is sent as []
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+func nilIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
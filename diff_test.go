@@ -0,0 +1,236 @@
+package goakeneo
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testDiffSchema returns the attribute types of the compared products
+func testDiffSchema() *Schema {
+	return &Schema{Attributes: []Attribute{
+		{Code: "name", Type: AttributeTypeText},
+		{Code: "color", Type: AttributeTypeSimpleSelect},
+		{Code: "sizes", Type: AttributeTypeMultiSelect},
+		{Code: "price", Type: AttributeTypePrice},
+		{Code: "weight", Type: AttributeTypeMetric},
+		{Code: "width", Type: AttributeTypeNumber},
+		{Code: "stock", Type: AttributeTypeNumber},
+		{Code: "ref", Type: AttributeTypeText},
+		{Code: "version", Type: AttributeTypeText},
+		{Code: "nutrition", Type: AttributeTypeTable},
+		{Code: "pictures", Type: AttributeTypeAssetCollection},
+		{Code: "designers", Type: AttributeTypeReferenceEntityCollection},
+	}}
+}
+
+func TestDiff(t *testing.T) {
+	en, fr, ecommerce := "en_US", "fr_FR", "ecommerce"
+	var old Product
+	assert.NoError(t, json.Unmarshal([]byte(`{
+		"identifier": "shirt",
+		"enabled": true,
+		"family": "shirts",
+		"categories": ["men", "summer"],
+		"values": {
+			"name": [
+				{"locale": "en_US", "scope": null, "data": "Shirt"},
+				{"locale": "fr_FR", "scope": null, "data": "Chemise"}
+			],
+			"price": [{"locale": null, "scope": "ecommerce", "data": [
+				{"amount": "10.0000", "currency": "EUR"},
+				{"amount": "12.5000", "currency": "USD"}
+			]}],
+			"sizes": [{"locale": null, "scope": null, "data": ["s", "m"], "linked_data": {}}]
+		},
+		"associations": {"X_SELL": {"products": ["pants", "belt"]}}
+	}`), &old))
+
+	same := Product{
+		Identifier: "shirt",
+		Enabled:    true,
+		Family:     "shirts",
+		Categories: []string{"summer", "men"},
+		Values: map[string][]ProductValue{
+			"name": {{Locale: &fr, Data: "Chemise"}, {Locale: &en, Data: "Shirt"}},
+			"price": {{Scope: &ecommerce, Data: []map[string]any{
+				{"amount": 12.5, "currency": "USD"},
+				{"amount": 10, "currency": "EUR"},
+			}}},
+			"sizes": {{Data: []string{"m", "s"}}},
+		},
+		Associations: map[string]association{"X_SELL": {Products: []string{"belt", "pants"}}},
+	}
+	assert.Empty(t, Diff(old, same, testDiffSchema()), "the order of the sets and the format of the amounts are ignored")
+
+	changed := same
+	changed.Categories = []string{"men"}
+	changed.Values = map[string][]ProductValue{
+		"name":  {{Locale: &en, Data: "T-shirt"}},
+		"price": same.Values["price"],
+		"sizes": same.Values["sizes"],
+		"color": {{Data: "red"}},
+	}
+	changes := Diff(old, changed, testDiffSchema())
+	assert.Equal(t, []ProductChange{
+		{Field: ProductFieldCategories, Old: []string{"men", "summer"}, New: []string{"men"}},
+		{Field: ProductFieldValues, Attribute: "color", New: "red"},
+		{Field: ProductFieldValues, Attribute: "name", Locale: "en_US", Old: "Shirt", New: "T-shirt"},
+		{Field: ProductFieldValues, Attribute: "name", Locale: "fr_FR", Old: "Chemise"},
+	}, changes)
+}
+
+func TestMinimalPatch(t *testing.T) {
+	en := "en_US"
+	old := Product{
+		Identifier: "shirt",
+		Enabled:    true,
+		Family:     "shirts",
+		Values: map[string][]ProductValue{
+			"name":  {{Locale: &en, Data: "Shirt"}},
+			"color": {{Data: "red"}},
+		},
+		Associations: map[string]association{"X_SELL": {Products: []string{"pants"}}},
+	}
+	updated := old
+	updated.Parent = "shirt_model"
+	updated.Values = map[string][]ProductValue{
+		"name": {{Locale: &en, Data: "T-shirt"}},
+	}
+	body, err := json.Marshal(MinimalPatch(old, updated, testDiffSchema()))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"identifier": "shirt",
+		"parent": "shirt_model",
+		"values": {
+			"color": [{"locale": null, "scope": null, "data": null}],
+			"name": [{"locale": "en_US", "scope": null, "data": "T-shirt"}]
+		}
+	}`, string(body))
+
+	old.Categories = []string{"men"}
+	removed := old
+	removed.Enabled = false
+	removed.Family = ""
+	removed.Categories = nil
+	removed.Associations = map[string]association{"X_SELL": {}}
+	body, err = json.Marshal(MinimalPatch(old, removed, testDiffSchema()))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"identifier": "shirt",
+		"enabled": false,
+		"family": null,
+		"categories": [],
+		"associations": {"X_SELL": {"groups": [], "products": [], "product_models": []}}
+	}`, string(body), "the patch disables the product, removes the family and empties the lists")
+}
+
+func TestProductOp_UpsertProductPatches(t *testing.T) {
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, productBasePath, r.URL.Path)
+		assert.Equal(t, []map[string]any{
+			{"identifier": "shirt", "enabled": false},
+			{"identifier": "pants", "family": nil, "categories": []any{}},
+		}, readCollection[map[string]any](t, r))
+		writeCollection(w, PatchProductResponse{
+			{Line: 1, Identifier: "shirt", StatusCode: http.StatusNoContent},
+			{Line: 2, Identifier: "pants", StatusCode: http.StatusUnprocessableEntity, Message: "Validation failed."},
+		})
+	}), WithVersion(AkeneoPimVersion6))
+	lines, err := c.Product.UpsertProductPatches([]ProductPatch{
+		MinimalPatch(Product{Identifier: "shirt", Enabled: true}, Product{Identifier: "shirt"}, nil),
+		MinimalPatch(Product{Identifier: "pants", Family: "pants", Categories: []string{"men"}}, Product{Identifier: "pants"}, nil),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, PatchProductResponse{
+		{Line: 1, Identifier: "shirt", StatusCode: http.StatusNoContent},
+		{Line: 2, Identifier: "pants", StatusCode: http.StatusUnprocessableEntity, Message: "Validation failed."},
+	}, lines)
+}
+
+func TestDiff_Numbers(t *testing.T) {
+	var old Product
+	assert.NoError(t, json.Unmarshal([]byte(`{
+		"identifier": "shirt",
+		"values": {
+			"weight": [{"locale": null, "scope": null, "data": {"amount": "1.2500", "unit": "KILOGRAM"}}],
+			"width": [{"locale": null, "scope": null, "data": "12.5000"}],
+			"stock": [{"locale": null, "scope": null, "data": 12}],
+			"ref": [{"locale": null, "scope": null, "data": "007"}],
+			"version": [{"locale": null, "scope": null, "data": "1.50"}]
+		}
+	}`), &old))
+	same := Product{
+		Identifier: "shirt",
+		Values: map[string][]ProductValue{
+			"weight":  {{Data: map[string]any{"amount": 1.25, "unit": "KILOGRAM"}}},
+			"width":   {{Data: 12.5}},
+			"stock":   {{Data: "12.0000"}},
+			"ref":     {{Data: "007"}},
+			"version": {{Data: "1.50"}},
+		},
+	}
+	assert.Empty(t, Diff(old, same, testDiffSchema()), "the decimal strings of the PIM equal the numbers")
+	assert.Equal(t, ProductPatch{"identifier": "shirt"}, MinimalPatch(old, same, testDiffSchema()))
+
+	var attributes []string
+	for _, change := range Diff(old, same, nil) {
+		attributes = append(attributes, change.Attribute)
+	}
+	assert.Equal(t, []string{"stock", "weight", "width"}, attributes, "without schema the values are compared as they are")
+
+	changed := same
+	changed.Values = map[string][]ProductValue{
+		"weight":  same.Values["weight"],
+		"width":   {{Data: "12.5001"}},
+		"stock":   same.Values["stock"],
+		"ref":     {{Data: "7"}},
+		"version": {{Data: "1.5"}},
+	}
+	assert.Equal(t, []ProductChange{
+		{Field: ProductFieldValues, Attribute: "ref", Old: "007", New: "7"},
+		{Field: ProductFieldValues, Attribute: "version", Old: "1.50", New: "1.5"},
+		{Field: ProductFieldValues, Attribute: "width", Old: "12.5000", New: "12.5001"},
+	}, Diff(old, changed, testDiffSchema()), "the text values are not numbers")
+}
+
+func TestDiff_OrderedLists(t *testing.T) {
+	rows := func(first, second string) map[string][]ProductValue {
+		return map[string][]ProductValue{"nutrition": {{Data: []map[string]any{
+			{"ingredient": first, "quantity": 1},
+			{"ingredient": second, "quantity": 2},
+		}}}}
+	}
+	old := Product{Identifier: "cake", Values: rows("sugar", "flour")}
+	reordered := Product{Identifier: "cake", Values: rows("flour", "sugar")}
+	changes := Diff(old, reordered, testDiffSchema())
+	if assert.Len(t, changes, 1, "the rows of a table keep their order") {
+		assert.Equal(t, "nutrition", changes[0].Attribute)
+	}
+
+	collections := func(pictures, designers, sizes []string) map[string][]ProductValue {
+		return map[string][]ProductValue{
+			"pictures":  {{Data: pictures}},
+			"designers": {{Data: designers}},
+			"sizes":     {{Data: sizes}},
+		}
+	}
+	old.Values = collections([]string{"front", "back"}, []string{"starck", "eames"}, []string{"s", "m"})
+	reordered.Values = collections([]string{"back", "front"}, []string{"eames", "starck"}, []string{"m", "s"})
+	assert.Equal(t, []ProductChange{
+		{Field: ProductFieldValues, Attribute: "designers", Old: []string{"starck", "eames"}, New: []string{"eames", "starck"}},
+		{Field: ProductFieldValues, Attribute: "pictures", Old: []string{"front", "back"}, New: []string{"back", "front"}},
+	}, Diff(old, reordered, testDiffSchema()), "the collections keep their order, the first asset is the main image")
+
+	old.QuantifiedAssociations = map[string]quantifiedAssociation{"PACK": {Products: []productQuantity{
+		{Identifier: "fork", Quantity: 2}, {Identifier: "knife", Quantity: 1},
+	}}}
+	reordered.Values = old.Values
+	reordered.QuantifiedAssociations = map[string]quantifiedAssociation{"PACK": {Products: []productQuantity{
+		{Identifier: "knife", Quantity: 1}, {Identifier: "fork", Quantity: 2},
+	}}}
+	assert.Empty(t, Diff(old, reordered, testDiffSchema()), "the quantified associations are sets")
+}
@@ -1,9 +1,11 @@
 package goakeneo
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/mitchellh/mapstructure"
 	"github.com/pkg/errors"
@@ -69,17 +71,18 @@ type Product struct {
 	Created                string                           `json:"created,omitempty" mapstructure:"created"`
 	Updated                string                           `json:"updated,omitempty" mapstructure:"updated"`
 	QualityScores          []QualityScore                   `json:"quality_scores,omitempty" mapstructure:"quality_scores"` // Since Akeneo 5.0,WithQualityScores must be true in the request
-	Completenesses         []any                            `json:"completenesses,omitempty" mapstructure:"completenesses"` // Since Akeneo 6.0,WithCompleteness must be true in the request
+	Completenesses         []Completeness                   `json:"completenesses,omitempty" mapstructure:"completenesses"` // Since Akeneo 6.0,WithCompleteness must be true in the request
 	Metadata               map[string]string                `json:"metadata,omitempty" mapstructure:"metadata"`             // Enterprise Edition only
 }
 
 // Links is the struct for akeneo links
 type Links struct {
-	Self     Link `json:"self,omitempty"`
-	First    Link `json:"first,omitempty"`
-	Previous Link `json:"previous,omitempty"`
-	Next     Link `json:"next,omitempty"`
-	Download Link `json:"download,omitempty"`
+	Self          Link `json:"self,omitempty"`
+	First         Link `json:"first,omitempty"`
+	Previous      Link `json:"previous,omitempty"`
+	Next          Link `json:"next,omitempty"`
+	Download      Link `json:"download,omitempty"`
+	ImageDownload Link `json:"image_download,omitempty"` // reference entities and asset families only
 }
 
 // HasNext returns true if there is a next link
@@ -551,6 +554,13 @@ type QualityScore struct {
 	Data   string `json:"data,omitempty" validate:"required"`
 }
 
+// Completeness is the struct for a product completeness of a channel and a locale
+type Completeness struct {
+	Scope  string `json:"scope,omitempty" mapstructure:"scope"`
+	Locale string `json:"locale,omitempty" mapstructure:"locale"`
+	Data   int    `json:"data" mapstructure:"data"` // completeness percentage, from 0 to 100
+}
+
 // Family is the struct for an akeneo family
 type Family struct {
 	Links                 *Links              `json:"_links,omitempty" mapstructure:"_links"`
@@ -610,6 +620,28 @@ type Attribute struct {
 	TableConfiguration  []string          `json:"table_configuration,omitempty" mapstructure:"table_configuration"`       // the table configuration of the attribute
 }
 
+// Attribute types
+const (
+	AttributeTypeIdentifier                = "pim_catalog_identifier"
+	AttributeTypeText                      = "pim_catalog_text"
+	AttributeTypeTextarea                  = "pim_catalog_textarea"
+	AttributeTypeBoolean                   = "pim_catalog_boolean"
+	AttributeTypeNumber                    = "pim_catalog_number"
+	AttributeTypeMetric                    = "pim_catalog_metric"
+	AttributeTypePrice                     = "pim_catalog_price_collection"
+	AttributeTypeDate                      = "pim_catalog_date"
+	AttributeTypeSimpleSelect              = "pim_catalog_simpleselect"
+	AttributeTypeMultiSelect               = "pim_catalog_multiselect"
+	AttributeTypeFile                      = "pim_catalog_file"
+	AttributeTypeImage                     = "pim_catalog_image"
+	AttributeTypeTable                     = "pim_catalog_table"
+	AttributeTypeAssetCollection           = "pim_catalog_asset_collection"
+	AttributeTypeReferenceDataSimpleSelect = "pim_reference_data_simpleselect"
+	AttributeTypeReferenceDataMultiSelect  = "pim_reference_data_multiselect"
+	AttributeTypeReferenceEntity           = "akeneo_reference_entity"
+	AttributeTypeReferenceEntityCollection = "akeneo_reference_entity_collection"
+)
+
 // AttributeOption is the struct for an akeneo attribute option,see:
 type AttributeOption struct {
 	Links     *Links            `json:"_links,omitempty" mapstructure:"_links"`
@@ -627,17 +659,116 @@ type Category struct {
 	Updated  *string                  `json:"updated,omitempty" mapstructure:"updated"`
 	Position *int                     `json:"position,omitempty" mapstructure:"position"` // since 7.0 with query parameter "with_positions=true"
 	Labels   map[string]string        `json:"labels,omitempty" mapstructure:"labels"`
-	Values   map[string]categoryValue `json:"values,omitempty" mapstructure:"values"`
+	Values   map[string]CategoryValue `json:"values,omitempty" mapstructure:"values"` // since 7.0 with query parameter "with_enriched_attributes=true", keyed by CategoryValueKey
+}
+
+// Value returns the category value of an attribute for the channel and locale
+// attribute can be the attribute code alone or the attribute code with uuid, i.e. "description|96b88bf4-c2b7-4b64-a1f9-5d4876c02c26"
+// channel and locale should be empty when the attribute is not scopable or localizable
+func (c Category) Value(attribute, channel, locale string) (CategoryValue, bool) {
+	for _, v := range c.Values {
+		if v.Channel != channel || v.Locale != locale {
+			continue
+		}
+		if v.AttributeCode == attribute || v.Code() == attribute {
+			return v, true
+		}
+	}
+	return CategoryValue{}, false
 }
 
-// categoryValue is the struct for an akeneo category value
-// todo : Data field is not yet implemented well
-type categoryValue struct {
-	Data          any    `json:"data,omitempty" mapstructure:"data"`           //  AttributeValue
-	Type          string `json:"type,omitempty" mapstructure:"type"`           //  AttributeType
-	Locale        string `json:"locale,omitempty" mapstructure:"locale"`       //  AttributeLocale
-	Channel       string `json:"channel,omitempty" mapstructure:"channel"`     //  AttributeChannel
-	AttributeCode string `json:"attribute,omitempty" mapstructure:"attribute"` //  AttributeCode with uuid, i.e. "description|96b88bf4-c2b7-4b64-a1f9-5d4876c02c26"
+// Category value types
+const (
+	CategoryValueTypeText     = "text"
+	CategoryValueTypeTextarea = "textarea"
+	CategoryValueTypeRichText = "richtext"
+	CategoryValueTypeImage    = "image"
+)
+
+// CategoryValueKey returns the key of a category value in Category.Values
+// i.e. "description|96b88bf4-c2b7-4b64-a1f9-5d4876c02c26|ecommerce|en_US"
+func CategoryValueKey(attributeCode, channel, locale string) string {
+	key := attributeCode
+	if channel != "" {
+		key += "|" + channel
+	}
+	if locale != "" {
+		key += "|" + locale
+	}
+	return key
+}
+
+// CategoryValue is the struct for an akeneo category value
+// text, textarea and richtext : data is a string
+// image : data is a CategoryImage, use Image() to get it
+type CategoryValue struct {
+	Data          any    `json:"data" mapstructure:"data"`                               //  AttributeValue
+	Type          string `json:"type,omitempty" mapstructure:"type"`                     //  AttributeType
+	Locale        string `json:"locale,omitempty" mapstructure:"locale"`                 //  AttributeLocale
+	Channel       string `json:"channel,omitempty" mapstructure:"channel"`               //  AttributeChannel
+	AttributeCode string `json:"attribute_code,omitempty" mapstructure:"attribute_code"` //  AttributeCode with uuid, i.e. "description|96b88bf4-c2b7-4b64-a1f9-5d4876c02c26"
+	Links         *Links `json:"_links,omitempty" mapstructure:"_links"`                 // only for image values
+}
+
+// Code returns the attribute code without the uuid
+func (v CategoryValue) Code() string {
+	code, _, _ := strings.Cut(v.AttributeCode, "|")
+	return code
+}
+
+// UUID returns the attribute uuid
+func (v CategoryValue) UUID() string {
+	_, id, _ := strings.Cut(v.AttributeCode, "|")
+	return id
+}
+
+// Key returns the key of the value in Category.Values
+func (v CategoryValue) Key() string {
+	return CategoryValueKey(v.AttributeCode, v.Channel, v.Locale)
+}
+
+// Text returns the data of a text, textarea or richtext value
+func (v CategoryValue) Text() (string, bool) {
+	switch v.Type {
+	case CategoryValueTypeText, CategoryValueTypeTextarea, CategoryValueTypeRichText:
+	default:
+		return "", false
+	}
+	s, ok := v.Data.(string)
+	return s, ok
+}
+
+// Image returns the data of an image value
+func (v CategoryValue) Image() (*CategoryImage, error) {
+	if v.Type != CategoryValueTypeImage {
+		return nil, errors.Errorf("category value %s is not an image but %s", v.AttributeCode, v.Type)
+	}
+	if v.Data == nil {
+		return nil, nil
+	}
+	image := new(CategoryImage)
+	if err := mapstructure.Decode(v.Data, image); err != nil {
+		return nil, errors.Wrap(err, "invalid category image data")
+	}
+	return image, nil
+}
+
+// DownloadURL returns the download url of an image value
+func (v CategoryValue) DownloadURL() string {
+	if v.Links != nil {
+		return v.Links.Download.Href
+	}
+	return ""
+}
+
+// CategoryImage is the data of an akeneo category image value
+// FilePath is the code to use with CategoryService.DownloadMediaFile
+type CategoryImage struct {
+	Size             int    `json:"size,omitempty" mapstructure:"size"`
+	Extension        string `json:"extension,omitempty" mapstructure:"extension"`
+	FilePath         string `json:"file_path,omitempty" mapstructure:"file_path"`
+	MimeType         string `json:"mime_type,omitempty" mapstructure:"mime_type"`
+	OriginalFilename string `json:"original_filename,omitempty" mapstructure:"original_filename"`
 }
 
 // Channel is the struct for an akeneo channel
@@ -651,6 +782,36 @@ type Channel struct {
 	Labels          map[string]string `json:"labels,omitempty" mapstructure:"labels"`
 }
 
+// Currency is the struct for an akeneo currency
+type Currency struct {
+	Links   *Links `json:"_links,omitempty" mapstructure:"_links"`
+	Code    string `json:"code,omitempty" mapstructure:"code"`
+	Enabled bool   `json:"enabled,omitempty" mapstructure:"enabled"`
+	Label   string `json:"label,omitempty" mapstructure:"label"` // since 7.0
+}
+
+// MeasurementFamily is the struct for an akeneo measurement family
+type MeasurementFamily struct {
+	Code             string                     `json:"code,omitempty" mapstructure:"code"`
+	Labels           map[string]string          `json:"labels,omitempty" mapstructure:"labels"`
+	StandardUnitCode string                     `json:"standard_unit_code,omitempty" mapstructure:"standard_unit_code"`
+	Units            map[string]MeasurementUnit `json:"units,omitempty" mapstructure:"units"`
+}
+
+// MeasurementUnit is the struct for an akeneo measurement family unit
+type MeasurementUnit struct {
+	Code                string                 `json:"code,omitempty" mapstructure:"code"`
+	Labels              map[string]string      `json:"labels,omitempty" mapstructure:"labels"`
+	ConvertFromStandard []MeasurementOperation `json:"convert_from_standard,omitempty" mapstructure:"convert_from_standard"` // operations to apply to convert a value of this unit to the standard unit
+	Symbol              string                 `json:"symbol,omitempty" mapstructure:"symbol"`
+}
+
+// MeasurementOperation is the struct for an akeneo measurement unit conversion operation
+type MeasurementOperation struct {
+	Operator string `json:"operator,omitempty" mapstructure:"operator"` // one of "mul", "div", "add", "sub"
+	Value    string `json:"value,omitempty" mapstructure:"value"`
+}
+
 // Locale is the struct for an akeneo locale
 type Locale struct {
 	Links   *Links `json:"_links,omitempty" mapstructure:"_links"`
@@ -672,3 +833,234 @@ type MediaFile struct {
 func (m *MediaFile) DownloadURL() string {
 	return m.Links.Download.Href
 }
+
+// Reference entity attribute types
+const (
+	ReferenceEntityAttributeTypeText           = "text"
+	ReferenceEntityAttributeTypeImage          = "image"
+	ReferenceEntityAttributeTypeNumber         = "number"
+	ReferenceEntityAttributeTypeSingleOption   = "single_option"
+	ReferenceEntityAttributeTypeMultipleOption = "multiple_options"
+	ReferenceEntityAttributeTypeSingleLink     = "reference_entity_single_link"
+	ReferenceEntityAttributeTypeMultipleLinks  = "reference_entity_multiple_links"
+)
+
+// ReferenceEntity is the struct for an akeneo reference entity, Enterprise Edition only
+type ReferenceEntity struct {
+	Links  *Links            `json:"_links,omitempty" mapstructure:"_links"`
+	Code   string            `json:"code,omitempty" mapstructure:"code"`
+	Labels map[string]string `json:"labels,omitempty" mapstructure:"labels"`
+	Image  *string           `json:"image,omitempty" mapstructure:"image"` // code of the reference entity media file
+}
+
+// ReferenceEntityAttribute is the struct for an akeneo reference entity attribute
+type ReferenceEntityAttribute struct {
+	Links                     *Links            `json:"_links,omitempty" mapstructure:"_links"`
+	Code                      string            `json:"code,omitempty" mapstructure:"code"`
+	Labels                    map[string]string `json:"labels,omitempty" mapstructure:"labels"`
+	Type                      string            `json:"type,omitempty" mapstructure:"type"`
+	ValuePerLocale            *bool             `json:"value_per_locale,omitempty" mapstructure:"value_per_locale"`
+	ValuePerChannel           *bool             `json:"value_per_channel,omitempty" mapstructure:"value_per_channel"`
+	IsRequiredForCompleteness *bool             `json:"is_required_for_completeness,omitempty" mapstructure:"is_required_for_completeness"`
+	MaxCharacters             *int              `json:"max_characters,omitempty" mapstructure:"max_characters"`               // text only
+	IsTextarea                *bool             `json:"is_textarea,omitempty" mapstructure:"is_textarea"`                     // text only
+	IsRichTextEditor          *bool             `json:"is_rich_text_editor,omitempty" mapstructure:"is_rich_text_editor"`     // text only
+	ValidationRule            *string           `json:"validation_rule,omitempty" mapstructure:"validation_rule"`             // text only
+	ValidationRegexp          *string           `json:"validation_regexp,omitempty" mapstructure:"validation_regexp"`         // text only
+	AllowedExtensions         []string          `json:"allowed_extensions,omitempty" mapstructure:"allowed_extensions"`       // image only
+	MaxFileSize               *string           `json:"max_file_size,omitempty" mapstructure:"max_file_size"`                 // image only
+	ReferenceEntityCode       *string           `json:"reference_entity_code,omitempty" mapstructure:"reference_entity_code"` // links only
+	DecimalsAllowed           *bool             `json:"decimals_allowed,omitempty" mapstructure:"decimals_allowed"`           // number only
+	MinValue                  *string           `json:"min_value,omitempty" mapstructure:"min_value"`                         // number only
+	MaxValue                  *string           `json:"max_value,omitempty" mapstructure:"max_value"`                         // number only
+}
+
+// ReferenceEntityAttributeOption is the struct for an akeneo reference entity attribute option
+type ReferenceEntityAttributeOption struct {
+	Links  *Links            `json:"_links,omitempty" mapstructure:"_links"`
+	Code   string            `json:"code,omitempty" mapstructure:"code"`
+	Labels map[string]string `json:"labels,omitempty" mapstructure:"labels"`
+}
+
+// ReferenceEntityRecord is the struct for an akeneo reference entity record
+type ReferenceEntityRecord struct {
+	Links   *Links                   `json:"_links,omitempty" mapstructure:"_links"`
+	Code    string                   `json:"code,omitempty" mapstructure:"code"`
+	Values  map[string][]RecordValue `json:"values,omitempty" mapstructure:"values"`
+	Created string                   `json:"created,omitempty" mapstructure:"created"`
+	Updated string                   `json:"updated,omitempty" mapstructure:"updated"`
+}
+
+// Value returns the value of an attribute for the channel and locale,
+// channel and locale should be empty when the attribute has no value per channel or per locale
+func (r ReferenceEntityRecord) Value(attribute, channel, locale string) (RecordValue, bool) {
+	return findRecordValue(r.Values[attribute], channel, locale)
+}
+
+// RecordValue is the struct for an akeneo reference entity record value or asset value
+// text, number, image, single option and single link : data is a string
+// multiple options and multiple links : data is a []string
+type RecordValue struct {
+	Channel *string `json:"channel" mapstructure:"channel"`
+	Locale  *string `json:"locale" mapstructure:"locale"`
+	Data    any     `json:"data" mapstructure:"data"`
+	Links   *Links  `json:"_links,omitempty" mapstructure:"_links"` // image values only
+}
+
+// Text returns the data of a text, image, single option or single link value
+func (v RecordValue) Text() (string, bool) {
+	s, ok := v.Data.(string)
+	return s, ok
+}
+
+// Codes returns the data of a multiple options or multiple links value
+func (v RecordValue) Codes() ([]string, bool) {
+	switch d := v.Data.(type) {
+	case []string:
+		return d, true
+	case []interface{}:
+		codes := make([]string, len(d))
+		for i, item := range d {
+			s, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			codes[i] = s
+		}
+		return codes, true
+	default:
+		return nil, false
+	}
+}
+
+// Number returns the data of a number value
+func (v RecordValue) Number() (float64, error) {
+	switch d := v.Data.(type) {
+	case string:
+		f, err := strconv.ParseFloat(d, 64)
+		if err != nil {
+			return 0, errors.Wrapf(err, "invalid number %q", d)
+		}
+		return f, nil
+	case float64:
+		return d, nil
+	case int:
+		return float64(d), nil
+	default:
+		return 0, errors.Errorf("invalid number %v", v.Data)
+	}
+}
+
+// DownloadURL returns the download url of an image value
+func (v RecordValue) DownloadURL() string {
+	if v.Links != nil {
+		return v.Links.Download.Href
+	}
+	return ""
+}
+
+func findRecordValue(values []RecordValue, channel, locale string) (RecordValue, bool) {
+	for _, v := range values {
+		vc, vl := "", ""
+		if v.Channel != nil {
+			vc = *v.Channel
+		}
+		if v.Locale != nil {
+			vl = *v.Locale
+		}
+		if vc == channel && vl == locale {
+			return v, true
+		}
+	}
+	return RecordValue{}, false
+}
+
+// Asset attribute types
+const (
+	AssetAttributeTypeText            = "text"
+	AssetAttributeTypeMediaFile       = "media_file"
+	AssetAttributeTypeMediaLink       = "media_link"
+	AssetAttributeTypeNumber          = "number"
+	AssetAttributeTypeSingleOption    = "single_option"
+	AssetAttributeTypeMultipleOptions = "multiple_options"
+)
+
+// AssetFamily is the struct for an akeneo asset family
+type AssetFamily struct {
+	Links                *Links            `json:"_links,omitempty" mapstructure:"_links"`
+	Code                 string            `json:"code,omitempty" mapstructure:"code"`
+	Labels               map[string]string `json:"labels,omitempty" mapstructure:"labels"`
+	AttributeAsMainMedia string            `json:"attribute_as_main_media,omitempty" mapstructure:"attribute_as_main_media"`
+	NamingConvention     map[string]any    `json:"naming_convention,omitempty" mapstructure:"naming_convention"`
+	ProductLinkRules     []map[string]any  `json:"product_link_rules,omitempty" mapstructure:"product_link_rules"`
+	Transformations      []map[string]any  `json:"transformations,omitempty" mapstructure:"transformations"`
+}
+
+// AssetAttribute is the struct for an akeneo asset family attribute
+type AssetAttribute struct {
+	Links                     *Links            `json:"_links,omitempty" mapstructure:"_links"`
+	Code                      string            `json:"code,omitempty" mapstructure:"code"`
+	Labels                    map[string]string `json:"labels,omitempty" mapstructure:"labels"`
+	Type                      string            `json:"type,omitempty" mapstructure:"type"`
+	ValuePerLocale            *bool             `json:"value_per_locale,omitempty" mapstructure:"value_per_locale"`
+	ValuePerChannel           *bool             `json:"value_per_channel,omitempty" mapstructure:"value_per_channel"`
+	IsRequiredForCompleteness *bool             `json:"is_required_for_completeness,omitempty" mapstructure:"is_required_for_completeness"`
+	IsReadOnly                *bool             `json:"is_read_only,omitempty" mapstructure:"is_read_only"`
+	MaxCharacters             *int              `json:"max_characters,omitempty" mapstructure:"max_characters"`           // text only
+	IsTextarea                *bool             `json:"is_textarea,omitempty" mapstructure:"is_textarea"`                 // text only
+	IsRichTextEditor          *bool             `json:"is_rich_text_editor,omitempty" mapstructure:"is_rich_text_editor"` // text only
+	ValidationRule            *string           `json:"validation_rule,omitempty" mapstructure:"validation_rule"`         // text only
+	ValidationRegexp          *string           `json:"validation_regexp,omitempty" mapstructure:"validation_regexp"`     // text only
+	AllowedExtensions         []string          `json:"allowed_extensions,omitempty" mapstructure:"allowed_extensions"`   // media file only
+	MaxFileSize               *string           `json:"max_file_size,omitempty" mapstructure:"max_file_size"`             // media file only
+	MediaType                 *string           `json:"media_type,omitempty" mapstructure:"media_type"`                   // media link only
+	Prefix                    *string           `json:"prefix,omitempty" mapstructure:"prefix"`                           // media link only
+	Suffix                    *string           `json:"suffix,omitempty" mapstructure:"suffix"`                           // media link only
+	DecimalsAllowed           *bool             `json:"decimals_allowed,omitempty" mapstructure:"decimals_allowed"`       // number only
+	MinValue                  *string           `json:"min_value,omitempty" mapstructure:"min_value"`                     // number only
+	MaxValue                  *string           `json:"max_value,omitempty" mapstructure:"max_value"`                     // number only
+}
+
+// AssetAttributeOption is the struct for an akeneo asset attribute option
+type AssetAttributeOption struct {
+	Links  *Links            `json:"_links,omitempty" mapstructure:"_links"`
+	Code   string            `json:"code,omitempty" mapstructure:"code"`
+	Labels map[string]string `json:"labels,omitempty" mapstructure:"labels"`
+}
+
+// Asset is the struct for an akeneo asset
+type Asset struct {
+	Links   *Links                   `json:"_links,omitempty" mapstructure:"_links"`
+	Code    string                   `json:"code,omitempty" mapstructure:"code"`
+	Values  map[string][]RecordValue `json:"values,omitempty" mapstructure:"values"`
+	Created string                   `json:"created,omitempty" mapstructure:"created"`
+	Updated string                   `json:"updated,omitempty" mapstructure:"updated"`
+}
+
+// Value returns the value of an attribute for the channel and locale,
+// channel and locale should be empty when the attribute has no value per channel or per locale
+func (a Asset) Value(attribute, channel, locale string) (RecordValue, bool) {
+	return findRecordValue(a.Values[attribute], channel, locale)
+}
+
+// Catalog is the struct for an akeneo app catalog
+type Catalog struct {
+	ID      string `json:"id,omitempty" mapstructure:"id"`
+	Name    string `json:"name,omitempty" mapstructure:"name"`
+	Enabled bool   `json:"enabled,omitempty" mapstructure:"enabled"`
+}
+
+// MappedProduct is a product of a catalog mapped with the catalog product mapping schema
+type MappedProduct map[string]any
+
+// Decode decodes the mapped product into v, v should be a pointer to a struct with json tags matching the mapping schema
+func (m MappedProduct) Decode(v any) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return errors.Wrap(err, "unable to marshal mapped product")
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return errors.Wrap(err, "unable to decode mapped product")
+	}
+	return nil
+}
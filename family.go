@@ -1,6 +1,7 @@
 package goakeneo
 
 import (
+	"context"
 	"path"
 )
 
@@ -14,9 +15,15 @@ type FamilyService interface {
 	ListWithPagination(options any) ([]Family, Links, error)
 	GetFamily(familyCode string, options any) (*Family, error)
 	GetFamilyVariants(familyCode string, options any) ([]FamilyVariant, error)
+	ListFamilyVariantsWithPagination(familyCode string, options any) ([]FamilyVariant, Links, error)
+	GetAllFamilyVariants(familyCode string) ([]FamilyVariant, error)
 	GetFamilyVariant(familyCode string, familyVariantCode string) (*FamilyVariant, error)
 	CreateFamily(family Family) error
+	UpdateFamily(familyCode string, family Family) error
+	UpsertFamilies(families []Family) (PatchProductResponse, error)
 	UpdateOrCreate(familyCode, familyVariantCode string, familyVariant FamilyVariant) error
+	UpsertFamilyVariants(familyCode string, familyVariants []FamilyVariant) (PatchProductResponse, error)
+	GetMany(ctx context.Context, codes []string, opts GetManyOptions) (GetManyResult[Family], error)
 }
 
 type familyOp struct {
@@ -55,7 +62,17 @@ func (f *familyOp) GetFamily(familyCode string, options any) (*Family, error) {
 }
 
 // GetFamilyVariants gets a family variants by code
+// only the page matching the options is returned, see GetAllFamilyVariants to get all of them
 func (f *familyOp) GetFamilyVariants(familyCode string, options any) ([]FamilyVariant, error) {
+	variants, _, err := f.ListFamilyVariantsWithPagination(familyCode, options)
+	if err != nil {
+		return nil, err
+	}
+	return variants, nil
+}
+
+// ListFamilyVariantsWithPagination lists a family variants with pagination
+func (f *familyOp) ListFamilyVariantsWithPagination(familyCode string, options any) ([]FamilyVariant, Links, error) {
 	sourcePath := path.Join(familyBasePath, familyCode, "variants")
 	result := new(FamilyVariantsResponse)
 	if err := f.client.GET(
@@ -64,9 +81,26 @@ func (f *familyOp) GetFamilyVariants(familyCode string, options any) ([]FamilyVa
 		nil,
 		result,
 	); err != nil {
-		return nil, err
+		return nil, Links{}, err
+	}
+	return result.Embedded.Items, result.Links, nil
+}
+
+// GetAllFamilyVariants gets all the variants of a family, following the pagination
+func (f *familyOp) GetAllFamilyVariants(familyCode string) ([]FamilyVariant, error) {
+	var result []FamilyVariant
+	variants, links, err := f.ListFamilyVariantsWithPagination(familyCode, FamilyVariantListOptions{Limit: 100})
+	for {
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, variants...)
+		if !links.HasNext() {
+			break
+		}
+		variants, links, err = f.ListFamilyVariantsWithPagination(familyCode, links.NextOptions())
 	}
-	return result.Embedded.Items, nil
+	return result, nil
 }
 
 // GetFamilyVariant gets a family variant by code
@@ -97,6 +131,25 @@ func (f *familyOp) CreateFamily(family Family) error {
 	return nil
 }
 
+// UpdateFamily updates a family by code, the family is created if it does not exist
+func (f *familyOp) UpdateFamily(familyCode string, family Family) error {
+	sourcePath := path.Join(familyBasePath, familyCode)
+	if err := f.client.PATCH(
+		sourcePath,
+		nil,
+		family,
+		nil,
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpsertFamilies updates or creates several families at once, returns the status of every family
+func (f *familyOp) UpsertFamilies(families []Family) (PatchProductResponse, error) {
+	return f.client.patchCollection(familyBasePath, families)
+}
+
 // UpdateOrCreate updates or creates a family variant
 func (f *familyOp) UpdateOrCreate(familyCode, familyVariantCode string, familyVariant FamilyVariant) error {
 	sourcePath := path.Join(familyBasePath, familyCode, "variants", familyVariantCode)
@@ -111,6 +164,12 @@ func (f *familyOp) UpdateOrCreate(familyCode, familyVariantCode string, familyVa
 	return nil
 }
 
+// UpsertFamilyVariants updates or creates several variants of a family at once, returns the status of every variant
+func (f *familyOp) UpsertFamilyVariants(familyCode string, familyVariants []FamilyVariant) (PatchProductResponse, error) {
+	sourcePath := path.Join(familyBasePath, familyCode, "variants")
+	return f.client.patchCollection(sourcePath, familyVariants)
+}
+
 // FamiliesResponse is the struct for an akeneo families response
 type FamiliesResponse struct {
 	Links       Links       `json:"_links,omitempty" mapstructure:"_links"`
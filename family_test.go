@@ -1,6 +1,12 @@
 package goakeneo
 
-import "testing"
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
 
 func TestFamilyOp_CreateFamily(t *testing.T) {
 	tests := []struct {
@@ -32,3 +38,80 @@ func TestFamilyOp_CreateFamily(t *testing.T) {
 		})
 	}
 }
+
+func TestFamilyOp_UpdateFamily(t *testing.T) {
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, familyBasePath+"/shoes", r.URL.Path)
+		var family Family
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&family))
+		assert.Equal(t, Family{Code: "shoes", AttributeAsLabel: "name"}, family)
+		w.WriteHeader(http.StatusNoContent)
+	}), WithVersion(AkeneoPimVersion6))
+	assert.NoError(t, c.Family.UpdateFamily("shoes", Family{Code: "shoes", AttributeAsLabel: "name"}))
+}
+
+func TestFamilyOp_UpsertFamilies(t *testing.T) {
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, familyBasePath, r.URL.Path)
+		assert.Equal(t, []Family{{Code: "shoes"}, {Code: "shirts"}}, readCollection[Family](t, r))
+		writeCollection(w, PatchProductResponse{
+			{Line: 1, Code: "shoes", StatusCode: http.StatusNoContent},
+			{Line: 2, Code: "shirts", StatusCode: http.StatusUnprocessableEntity, Message: "Validation failed."},
+		})
+	}), WithVersion(AkeneoPimVersion6))
+	lines, err := c.Family.UpsertFamilies([]Family{{Code: "shoes"}, {Code: "shirts"}})
+	assert.NoError(t, err)
+	assert.Equal(t, PatchProductResponse{
+		{Line: 1, Code: "shoes", StatusCode: http.StatusNoContent},
+		{Line: 2, Code: "shirts", StatusCode: http.StatusUnprocessableEntity, Message: "Validation failed."},
+	}, lines)
+}
+
+func TestFamilyOp_UpsertFamilyVariants(t *testing.T) {
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, familyBasePath+"/shoes/variants", r.URL.Path)
+		variants := readCollection[FamilyVariant](t, r)
+		if assert.Len(t, variants, 1) {
+			assert.Equal(t, "shoes_size", variants[0].Code)
+			assert.Equal(t, []string{"size"}, variants[0].VariantAttributeSets[0].Axes)
+		}
+		writeCollection(w, PatchProductResponse{{Line: 1, Code: "shoes_size", StatusCode: http.StatusCreated}})
+	}), WithVersion(AkeneoPimVersion6))
+	lines, err := c.Family.UpsertFamilyVariants("shoes", []FamilyVariant{{
+		Code:                 "shoes_size",
+		VariantAttributeSets: []VariantAttributeSet{{Level: 1, Axes: []string{"size"}}},
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, PatchProductResponse{{Line: 1, Code: "shoes_size", StatusCode: http.StatusCreated}}, lines)
+}
+
+func TestFamilyOp_GetAllFamilyVariants(t *testing.T) {
+	var pages []string
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, familyBasePath+"/shoes/variants", r.URL.Path)
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+		resp := FamilyVariantsResponse{}
+		if page == "" {
+			assert.Equal(t, "100", r.URL.Query().Get("limit"))
+			resp.Links.Next.Href = "http://" + r.Host + r.URL.Path + "?page=2&limit=100"
+			resp.Embedded.Items = []FamilyVariant{{Code: "shoes_size"}}
+		} else {
+			resp.Embedded.Items = []FamilyVariant{{Code: "shoes_color"}}
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}), WithVersion(AkeneoPimVersion6))
+
+	variants, links, err := c.Family.ListFamilyVariantsWithPagination("shoes", FamilyVariantListOptions{Limit: 100})
+	assert.NoError(t, err)
+	assert.Equal(t, []FamilyVariant{{Code: "shoes_size"}}, variants)
+	assert.True(t, links.HasNext())
+
+	variants, err = c.Family.GetAllFamilyVariants("shoes")
+	assert.NoError(t, err)
+	assert.Equal(t, []FamilyVariant{{Code: "shoes_size"}, {Code: "shoes_color"}}, variants)
+	assert.Equal(t, []string{"", "", "2"}, pages, "one page listed, then all the pages")
+}
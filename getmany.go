@@ -0,0 +1,302 @@
+package goakeneo
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"sync"
+)
+
+const (
+	defaultGetManyWorkers   = 4
+	defaultGetManyBatchSize = 100
+)
+
+// GetManyOptions specifies how GetMany fetches the entities
+type GetManyOptions struct {
+	Workers   int        // Workers is the number of concurrent requests, default 4, the client limiter still applies
+	BatchSize int        // BatchSize is the number of ids per search request, default and max 100
+	Query     url.Values // Query is added to every request, i.e. with_attribute_options for the products
+}
+
+func (o GetManyOptions) withDefaults() GetManyOptions {
+	if o.Workers <= 0 {
+		o.Workers = defaultGetManyWorkers
+	}
+	if o.BatchSize <= 0 || o.BatchSize > defaultGetManyBatchSize {
+		o.BatchSize = defaultGetManyBatchSize
+	}
+	return o
+}
+
+// GetManyResult is the result of a GetMany, keyed by the requested ids
+// every id is either in Items or in Errors
+type GetManyResult[T any] struct {
+	Items  map[string]T
+	Errors map[string]error
+}
+
+// getManyFetcher fetches the entities of a GetMany
+type getManyFetcher[T any] struct {
+	// search returns the entities matching the ids, nil when the entity does not support the IN search
+	search func(ids []string, query url.Values) ([]T, error)
+	// key returns the id of an entity returned by search
+	key func(T) string
+	// get gets one entity
+	get func(id string, query url.Values) (*T, error)
+}
+
+// getMany fetches the entities by batches of searches when supported, then with concurrent single GETs
+// for the ids the searches did not return, so that missing ids get the error of their GET
+func getMany[T any](ctx context.Context, client *Client, ids []string, opts GetManyOptions, f getManyFetcher[T]) (GetManyResult[T], error) {
+	opts = opts.withDefaults()
+	result := GetManyResult[T]{
+		Items:  make(map[string]T, len(ids)),
+		Errors: make(map[string]error),
+	}
+	ids = uniqueStrings(ids)
+	if len(ids) == 0 {
+		return result, nil
+	}
+	if !client.appToken {
+		// refresh the token once before the workers share it, so that they do not all wait for the refresh
+		if err := client.Auth.AutoRefreshToken(); err != nil {
+			for _, id := range ids {
+				result.Errors[id] = err
+			}
+			return result, err
+		}
+	}
+	var mu sync.Mutex
+	if f.search != nil {
+		var batches [][]string
+		for start := 0; start < len(ids); start += opts.BatchSize {
+			end := start + opts.BatchSize
+			if end > len(ids) {
+				end = len(ids)
+			}
+			batches = append(batches, ids[start:end])
+		}
+		runWorkers(ctx, opts.Workers, len(batches), func(i int) {
+			items, err := f.search(batches[i], searchQuery(opts.Query, opts.BatchSize))
+			if err != nil {
+				// the ids of the batch fall back to single GETs
+				return
+			}
+			requested := make(map[string]bool, len(batches[i]))
+			for _, id := range batches[i] {
+				requested[id] = true
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, item := range items {
+				if key := f.key(item); requested[key] {
+					result.Items[key] = item
+				}
+			}
+		})
+	}
+	var missing []string
+	for _, id := range ids {
+		if _, ok := result.Items[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	done := make([]bool, len(missing))
+	runWorkers(ctx, opts.Workers, len(missing), func(i int) {
+		item, err := f.get(missing[i], opts.Query)
+		mu.Lock()
+		defer mu.Unlock()
+		done[i] = true
+		if err != nil {
+			result.Errors[missing[i]] = err
+			return
+		}
+		result.Items[missing[i]] = *item
+	})
+	if err := ctx.Err(); err != nil {
+		for i, id := range missing {
+			if !done[i] {
+				result.Errors[id] = err
+			}
+		}
+		return result, err
+	}
+	return result, nil
+}
+
+// runWorkers calls fn for every index with n workers, it stops taking new indexes when the context is done
+func runWorkers(ctx context.Context, n, count int, fn func(i int)) {
+	if count == 0 {
+		return
+	}
+	if n > count {
+		n = count
+	}
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+	defer wg.Wait()
+	defer close(indexes)
+	for i := 0; i < count; i++ {
+		select {
+		case <-ctx.Done():
+			return
+		case indexes <- i:
+		}
+	}
+}
+
+// searchQuery returns a copy of the query for a search request of one page
+func searchQuery(query url.Values, limit int) url.Values {
+	values := url.Values{}
+	for key, v := range query {
+		values[key] = v
+	}
+	values.Set("limit", strconv.Itoa(limit))
+	values.Del("page")
+	values.Del("search_after")
+	return values
+}
+
+// uniqueStrings removes the duplicated and empty values
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+	for _, v := range values {
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		unique = append(unique, v)
+	}
+	return unique
+}
+
+// GetMany gets products by identifiers, or uuids since akeneo 7, with batches of "identifier IN" searches
+// and concurrent single GETs for the products the searches did not return
+func (p *productOp) GetMany(ctx context.Context, ids []string, opts GetManyOptions) (GetManyResult[Product], error) {
+	var identifiers, uuids []string
+	for _, id := range ids {
+		if p.client.osVersion >= AkeneoPimVersion7 && IsUUID(id) {
+			uuids = append(uuids, id)
+		} else {
+			identifiers = append(identifiers, id)
+		}
+	}
+	fetcher := func(filter string, key func(Product) string) getManyFetcher[Product] {
+		return getManyFetcher[Product]{
+			search: func(ids []string, query url.Values) ([]Product, error) {
+				query = withSearchFilter(query, filter, ids)
+				products, _, err := p.ListWithPagination(query)
+				for _, product := range products {
+					p.resolver.Remember(product)
+				}
+				return products, err
+			},
+			key: key,
+			get: func(id string, query url.Values) (*Product, error) {
+				return p.GetProduct(id, nonEmptyQuery(query))
+			},
+		}
+	}
+	result, err := getMany(ctx, p.client, identifiers, opts, fetcher("identifier", func(product Product) string {
+		return product.Identifier
+	}))
+	if len(uuids) == 0 {
+		return result, err
+	}
+	// the uuids are fetched even when the identifiers failed, so that every id gets an item or an error
+	byUUID, uuidErr := getMany(ctx, p.client, uuids, opts, fetcher("uuid", func(product Product) string {
+		return product.UUID
+	}))
+	for id, product := range byUUID.Items {
+		result.Items[id] = product
+	}
+	for id, e := range byUUID.Errors {
+		result.Errors[id] = e
+	}
+	if err != nil {
+		return result, err
+	}
+	return result, uuidErr
+}
+
+// GetMany gets product models by codes, with batches of "identifier IN" searches
+// and concurrent single GETs for the product models the searches did not return
+func (p *productModelOp) GetMany(ctx context.Context, codes []string, opts GetManyOptions) (GetManyResult[ProductModel], error) {
+	return getMany(ctx, p.client, codes, opts, getManyFetcher[ProductModel]{
+		search: func(codes []string, query url.Values) ([]ProductModel, error) {
+			models, _, err := p.ListWithPagination(withSearchFilter(query, "identifier", codes))
+			return models, err
+		},
+		key: func(model ProductModel) string { return model.Code },
+		get: func(code string, query url.Values) (*ProductModel, error) {
+			return p.GetProductModel(code, nonEmptyQuery(query))
+		},
+	})
+}
+
+// GetMany gets attributes by codes, with batches of "code IN" searches
+// and concurrent single GETs for the attributes the searches did not return
+func (c *attributeOp) GetMany(ctx context.Context, codes []string, opts GetManyOptions) (GetManyResult[Attribute], error) {
+	return getMany(ctx, c.client, codes, opts, getManyFetcher[Attribute]{
+		search: func(codes []string, query url.Values) ([]Attribute, error) {
+			attributes, _, err := c.ListWithPagination(withSearchFilter(query, "code", codes))
+			return attributes, err
+		},
+		key: func(attribute Attribute) string { return attribute.Code },
+		get: func(code string, query url.Values) (*Attribute, error) {
+			return c.GetAttribute(code, nonEmptyQuery(query))
+		},
+	})
+}
+
+// GetManyAttributeOptions gets options of an attribute by codes with concurrent single GETs,
+// the attribute options can not be searched
+func (c *attributeOp) GetManyAttributeOptions(ctx context.Context, attributeCode string, codes []string, opts GetManyOptions) (GetManyResult[AttributeOption], error) {
+	return getMany(ctx, c.client, codes, opts, getManyFetcher[AttributeOption]{
+		get: func(code string, _ url.Values) (*AttributeOption, error) {
+			return c.GetAttributeOption(attributeCode, code)
+		},
+	})
+}
+
+// GetMany gets families by codes, with batches of "code IN" searches
+// and concurrent single GETs for the families the searches did not return
+func (f *familyOp) GetMany(ctx context.Context, codes []string, opts GetManyOptions) (GetManyResult[Family], error) {
+	return getMany(ctx, f.client, codes, opts, getManyFetcher[Family]{
+		search: func(codes []string, query url.Values) ([]Family, error) {
+			families, _, err := f.ListWithPagination(withSearchFilter(query, "code", codes))
+			return families, err
+		},
+		key: func(family Family) string { return family.Code },
+		get: func(code string, query url.Values) (*Family, error) {
+			return f.GetFamily(code, nonEmptyQuery(query))
+		},
+	})
+}
+
+// withSearchFilter replaces the search of the query with the filter on the ids
+func withSearchFilter(query url.Values, filter string, ids []string) url.Values {
+	sf := make(SearchFilter)
+	sf.Add(filter, "IN", ids)
+	query.Set("search", sf.String())
+	return query
+}
+
+// nonEmptyQuery returns nil for an empty query, so that no options are sent
+func nonEmptyQuery(query url.Values) any {
+	if len(query) == 0 {
+		return nil
+	}
+	return query
+}
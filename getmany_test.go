@@ -0,0 +1,110 @@
+package goakeneo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProduct_GetMany(t *testing.T) {
+	var searches, gets int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == productBasePath:
+			atomic.AddInt32(&searches, 1)
+			var search SearchFilter
+			assert.NoError(t, json.Unmarshal([]byte(r.URL.Query().Get("search")), &search))
+			assert.Equal(t, "IN", search["identifier"][0]["operator"])
+			assert.Equal(t, "true", r.URL.Query().Get("with_attribute_options"))
+			// the search misses sku_3, i.e. an index lagging behind
+			resp := ProductsResponse{}
+			resp.Embedded.Items = []Product{{Identifier: "sku_1"}, {Identifier: "sku_2"}}
+			writeJSON(w, http.StatusOK, resp)
+		case r.URL.Path == productBasePath+"/sku_3":
+			atomic.AddInt32(&gets, 1)
+			writeJSON(w, http.StatusOK, Product{Identifier: "sku_3"})
+		default:
+			atomic.AddInt32(&gets, 1)
+			writeJSON(w, http.StatusNotFound, ErrorResponse{Code: http.StatusNotFound, Message: "Resource not found"})
+		}
+	}, WithVersion(AkeneoPimVersion6))
+	// ignore the system information requests of the init
+	atomic.StoreInt32(&searches, 0)
+	atomic.StoreInt32(&gets, 0)
+
+	opts := GetManyOptions{Workers: 2, BatchSize: 2, Query: map[string][]string{"with_attribute_options": {"true"}}}
+	result, err := c.Product.GetMany(context.Background(), []string{"sku_1", "sku_2", "sku_3", "sku_4", "sku_1"}, opts)
+	assert.NoError(t, err)
+	assert.Len(t, result.Items, 3)
+	assert.Equal(t, "sku_3", result.Items["sku_3"].Identifier)
+	if assert.Contains(t, result.Errors, "sku_4") {
+		assert.True(t, strings.Contains(result.Errors["sku_4"].Error(), "Resource not found"))
+	}
+	assert.Equal(t, int32(2), searches)
+	assert.Equal(t, int32(2), gets)
+}
+
+func TestAttribute_GetManyAttributeOptions(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		code := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		writeJSON(w, http.StatusOK, AttributeOption{Code: code, Attribute: "color"})
+	}, WithVersion(AkeneoPimVersion6))
+	result, err := c.Attribute.GetManyAttributeOptions(context.Background(), "color", []string{"red", "blue"}, GetManyOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, result.Items, 2)
+	assert.Equal(t, "blue", result.Items["blue"].Code)
+	assert.Empty(t, result.Errors)
+}
+
+func TestGetMany_Canceled(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, Family{Code: "shoes"})
+	}, WithVersion(AkeneoPimVersion6))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err := c.Family.GetMany(ctx, []string{"shoes", "shirts"}, GetManyOptions{})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, result.Errors["shirts"], context.Canceled)
+}
+
+func TestProduct_GetManyEveryID(t *testing.T) {
+	const uuid = "1fd20ad8-ef95-49d7-a581-fb9f8ac0c5ad"
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, ProductsResponse{})
+	}), WithVersion(AkeneoPimVersion7))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err := c.Product.GetMany(ctx, []string{"sku_1", uuid}, GetManyOptions{})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, result.Errors["sku_1"], context.Canceled)
+	assert.ErrorIs(t, result.Errors[uuid], context.Canceled, "the uuids are fetched when the identifiers failed")
+}
+
+func TestGetMany_TokenRefresh(t *testing.T) {
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		code := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		writeJSON(w, http.StatusOK, AttributeOption{Code: code})
+	}), WithVersion(AkeneoPimVersion6))
+	codes := make([]string, 50)
+	for i := range codes {
+		codes[i] = "option_" + strconv.Itoa(i)
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		// the token is rewritten while the workers read it
+		for i := 0; i < 10; i++ {
+			assert.NoError(t, c.Auth.GrantByRefreshToken())
+		}
+	}()
+	result, err := c.Attribute.GetManyAttributeOptions(context.Background(), "color", codes, GetManyOptions{Workers: 8})
+	<-done
+	assert.NoError(t, err)
+	assert.Len(t, result.Items, 50)
+}
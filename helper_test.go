@@ -0,0 +1,112 @@
+package goakeneo
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testRateLimit is the rate limit of the test clients, in requests per second
+const testRateLimit = 10000
+
+// newTestClient creates a client against a local server,
+// the token endpoint is served by the helper and the other requests by the handler,
+// the rate limit is raised so that the tests are not throttled, pass WithRateLimit or WithLimiter to override it
+func newTestClient(t *testing.T, handler http.HandlerFunc, opts ...Option) *Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/"+authBasePath {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(authResponse{
+				AccessToken:  "access_token",
+				RefreshToken: "refresh_token",
+				ExpiresIn:    3600,
+				TokenType:    "bearer",
+			})
+			return
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	con := Connector{
+		ClientID: "client_id",
+		Secret:   "secret",
+		UserName: "username",
+		Password: "password",
+	}
+	c, err := NewClient(con, append([]Option{WithBaseURL(srv.URL), WithRateLimit(testRateLimit, time.Second)}, opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// apiOnly answers the system information request of the client init with a 404,
+// so that the handler only sees the requests of the tested service, use it with WithVersion
+func apiOnly(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == systemInformationBasePath {
+			http.NotFound(w, r)
+			return
+		}
+		handler(w, r)
+	}
+}
+
+// writeJSON writes a json response
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// readCollection decodes the body of a bulk PATCH request, one json object per line
+func readCollection[T any](t *testing.T, r *http.Request) []T {
+	t.Helper()
+	assert.Equal(t, defaultCollectionType, r.Header.Get("Content-Type"))
+	var items []T
+	decoder := json.NewDecoder(r.Body)
+	for decoder.More() {
+		var item T
+		assert.NoError(t, decoder.Decode(&item))
+		items = append(items, item)
+	}
+	return items
+}
+
+// writeCollection writes the response of a bulk PATCH request, one json object per line
+func writeCollection(w http.ResponseWriter, lines PatchProductResponse) {
+	w.Header().Set("Content-Type", defaultCollectionType)
+	w.WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(w)
+	for _, line := range lines {
+		_ = encoder.Encode(line)
+	}
+}
+
+// newCassetteClient creates a client replaying testdata/cassettes/<test name>.json, the requests never reach the network.
+// the cassettes in testdata are hand-written after the responses of the API reference,
+// the test fails when the cassette is missing
+func newCassetteClient(t *testing.T, opts ...Option) *Client {
+	t.Helper()
+	cassette, err := NewCassette(filepath.Join("testdata", "cassettes", t.Name()+".json"), CassetteReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	con := Connector{
+		ClientID: "client_id",
+		Secret:   "secret",
+		UserName: "username",
+		Password: "password",
+	}
+	c, err := NewClient(con, append([]Option{WithBaseURL(mockBaseURL), WithCassette(cassette)}, opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
@@ -0,0 +1,310 @@
+package goakeneo
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Hooks are called by the client on its events, they are used by the instrumentation packages
+// hooks are called synchronously and should not block, nil hooks are ignored
+type Hooks struct {
+	OnRequest      func(RequestEvent) // called once a request is done, retries included
+	OnRetry        func(RetryEvent)   // called before a request is retried
+	OnToken        func(TokenEvent)   // called when a token is granted or the grant failed
+	OnBulkResponse func(BulkEvent)    // called with the response of a bulk upsert
+}
+
+// RequestEvent describes a request done by the client
+type RequestEvent struct {
+	Method        string
+	Path          string
+	Resource      string // see EndpointOf
+	Operation     string // see EndpointOf
+	StatusCode    int    // 0 when no response has been received
+	Attempts      int
+	Duration      time.Duration
+	RateLimitWait time.Duration
+	Err           error
+}
+
+// RetryEvent describes a retry of a request
+type RetryEvent struct {
+	Method     string
+	Path       string
+	Resource   string
+	Operation  string
+	Attempt    int
+	StatusCode int
+	Err        error
+}
+
+// TokenEvent describes a token grant
+type TokenEvent struct {
+	GrantType string // "password" or "refresh_token"
+	Err       error
+}
+
+// BulkEvent describes the response of a bulk upsert, one status per line
+type BulkEvent struct {
+	Path     string
+	Resource string
+	Lines    int
+	Failures int // lines with a status code >= 400
+}
+
+// WithHooks adds hooks to the client, several hooks can be added
+func WithHooks(hooks Hooks) Option {
+	return func(c *Client) {
+		c.hooks = append(c.hooks, hooks)
+	}
+}
+
+// route is a path template of the API, "*" matches one segment, "**" one or more segments, i.e. a media file code.
+// the resource of a route is its fixed segments, item is true when the route is one item of the resource
+type route struct {
+	segments []string
+	item     bool
+}
+
+func newRoute(template string, item bool) route {
+	return route{segments: strings.Split(template, "/"), item: item}
+}
+
+// routes are the path templates of the requests sent by the client, relative to the API prefixes
+var routes = func() []route {
+	var list []route
+	for _, resource := range []string{"products", "products-uuid", "product-models"} {
+		list = append(list,
+			newRoute(resource, false),
+			newRoute(resource+"/*", true),
+			newRoute(resource+"/*/draft", true),
+			newRoute(resource+"/*/proposal", true),
+			newRoute(resource+"/*/quality-scores", true),
+		)
+	}
+	for _, resource := range []string{"published-products", "categories", "channels", "currencies", "locales", "measurement-families"} {
+		list = append(list, newRoute(resource, false), newRoute(resource+"/*", true))
+	}
+	for _, sub := range [][2]string{{"families", "variants"}, {"attributes", "options"}} {
+		list = append(list,
+			newRoute(sub[0], false),
+			newRoute(sub[0]+"/*", true),
+			newRoute(sub[0]+"/*/"+sub[1], false),
+			newRoute(sub[0]+"/*/"+sub[1]+"/*", true),
+		)
+	}
+	for _, sub := range [][2]string{{"reference-entities", "records"}, {"asset-families", "assets"}} {
+		list = append(list,
+			newRoute(sub[0], false),
+			newRoute(sub[0]+"/*", true),
+			newRoute(sub[0]+"/*/attributes", false),
+			newRoute(sub[0]+"/*/attributes/*", true),
+			newRoute(sub[0]+"/*/attributes/*/options", false),
+			newRoute(sub[0]+"/*/attributes/*/options/*", true),
+			newRoute(sub[0]+"/*/"+sub[1], false),
+			newRoute(sub[0]+"/*/"+sub[1]+"/*", true),
+		)
+	}
+	for _, resource := range []string{"media-files", "category-media-files", "reference-entities-media-files", "asset-media-files"} {
+		list = append(list,
+			newRoute(resource, false),
+			newRoute(resource+"/**/download", true),
+			newRoute(resource+"/**", true),
+		)
+	}
+	list = append(list,
+		newRoute("jobs/export/*", true),
+		newRoute("jobs/import/*", true),
+		newRoute("catalogs", false),
+		newRoute("catalogs/*", true),
+		newRoute("catalogs/*/product-uuids", false),
+		newRoute("catalogs/*/products", false),
+		newRoute("catalogs/*/products/*", true),
+		newRoute("catalogs/*/mapped-products", false),
+		newRoute("catalogs/*/mapping-schemas/product", true),
+		newRoute("system-information", true),
+		newRoute("token", false),
+		newRoute("oauth2/token", false),
+	)
+	return list
+}()
+
+// unknownResource is the resource of the paths matching no route
+const unknownResource = "other"
+
+// EndpointOf returns the resource and the operation of a request from the route of its path,
+// the codes and ids of the path are never part of the resource, so that the resources have a low cardinality
+// i.e. "GET /api/rest/v1/families/shoes/variants" is resource "families/variants" and operation "list",
+// "POST /api/rest/v1/jobs/export/csv_export" is resource "jobs/export" and operation "create",
+// the paths matching no route are resource "other"
+func EndpointOf(method, rawPath string) (resource, operation string) {
+	p := rawPath
+	if u, err := url.Parse(rawPath); err == nil {
+		p = u.Path
+	}
+	p = strings.Trim(p, "/")
+	for _, prefix := range []string{"api/rest/v1/", "api/oauth/v1/", "connect/apps/v1/"} {
+		p = strings.TrimPrefix(p, prefix)
+	}
+	segments := strings.Split(p, "/")
+	resource = unknownResource
+	item := false
+	for _, r := range routes {
+		if matchRoute(r.segments, segments) {
+			var names []string
+			for _, segment := range r.segments {
+				if segment != "*" && segment != "**" {
+					names = append(names, segment)
+				}
+			}
+			resource, item = strings.Join(names, "/"), r.item
+			break
+		}
+	}
+	download := strings.HasSuffix(resource, "/download")
+	resource = strings.TrimSuffix(resource, "/download")
+	switch {
+	case download:
+		operation = "download"
+	case method == http.MethodGet && item:
+		operation = "get"
+	case method == http.MethodGet:
+		operation = "list"
+	case method == http.MethodPost:
+		operation = "create"
+	case method == http.MethodPatch && item:
+		operation = "update"
+	case method == http.MethodPatch:
+		operation = "upsert"
+	case method == http.MethodPut:
+		operation = "update"
+	case method == http.MethodDelete:
+		operation = "delete"
+	default:
+		operation = strings.ToLower(method)
+	}
+	return resource, operation
+}
+
+// matchRoute returns true when the segments of a path match the segments of a route template
+func matchRoute(template, segments []string) bool {
+	switch {
+	case len(template) == 0:
+		return len(segments) == 0
+	case len(segments) == 0:
+		return false
+	case template[0] == "**":
+		for i := 1; i <= len(segments); i++ {
+			if matchRoute(template[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	case template[0] == "*" && segments[0] != "":
+		return matchRoute(template[1:], segments[1:])
+	default:
+		return template[0] == segments[0] && matchRoute(template[1:], segments[1:])
+	}
+}
+
+// observeRequest logs a request once done and calls the request hooks
+func (c *Client) observeRequest(method, rawURL string, resp *resty.Response, err error, wait, duration time.Duration) {
+	c.logRequest(method, rawURL, resp, err, wait, duration)
+	if len(c.hooks) == 0 {
+		return
+	}
+	path := redactedPath(rawURL)
+	event := RequestEvent{
+		Method:        method,
+		Path:          path,
+		Duration:      duration,
+		RateLimitWait: wait,
+		Err:           err,
+	}
+	event.Resource, event.Operation = EndpointOf(method, path)
+	if resp != nil && resp.Request != nil {
+		event.Attempts = resp.Request.Attempt
+	}
+	if resp != nil && resp.RawResponse != nil {
+		event.StatusCode = resp.StatusCode()
+	}
+	for _, h := range c.hooks {
+		if h.OnRequest != nil {
+			h.OnRequest(event)
+		}
+	}
+}
+
+// observeRetry logs a retry and calls the retry hooks, it is a resty retry hook
+func (c *Client) observeRetry(resp *resty.Response, err error) {
+	c.logRetry(resp, err)
+	if len(c.hooks) == 0 {
+		return
+	}
+	event := RetryEvent{Err: err}
+	if resp != nil && resp.Request != nil {
+		event.Method = resp.Request.Method
+		event.Path = redactedPath(resp.Request.URL)
+		event.Attempt = resp.Request.Attempt
+		event.Resource, event.Operation = EndpointOf(event.Method, event.Path)
+	}
+	if resp != nil && resp.RawResponse != nil {
+		event.StatusCode = resp.StatusCode()
+	}
+	for _, h := range c.hooks {
+		if h.OnRetry != nil {
+			h.OnRetry(event)
+		}
+	}
+}
+
+// observeToken logs a token grant and calls the token hooks
+func (c *Client) observeToken(grantType string, err error) {
+	if err != nil {
+		c.logToken("akeneo token grant failed", grantType, err)
+	} else {
+		c.logToken("akeneo token granted", grantType, nil)
+	}
+	for _, h := range c.hooks {
+		if h.OnToken != nil {
+			h.OnToken(TokenEvent{GrantType: grantType, Err: err})
+		}
+	}
+}
+
+// observeBulkResponse calls the bulk hooks when the result of a request is a bulk upsert response
+func (c *Client) observeBulkResponse(relPath string, result any) {
+	if len(c.hooks) == 0 {
+		return
+	}
+	var lines, failures int
+	switch r := result.(type) {
+	case *PatchProductResponse:
+		lines = len(*r)
+		for _, line := range *r {
+			if line.StatusCode >= http.StatusBadRequest {
+				failures++
+			}
+		}
+	case *PatchMeasurementFamilyResponse:
+		lines = len(*r)
+		for _, line := range *r {
+			if line.StatusCode >= http.StatusBadRequest {
+				failures++
+			}
+		}
+	default:
+		return
+	}
+	resource, _ := EndpointOf(http.MethodPatch, relPath)
+	event := BulkEvent{Path: relPath, Resource: resource, Lines: lines, Failures: failures}
+	for _, h := range c.hooks {
+		if h.OnBulkResponse != nil {
+			h.OnBulkResponse(event)
+		}
+	}
+}
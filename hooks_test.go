@@ -0,0 +1,61 @@
+package goakeneo
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEndpointOf(t *testing.T) {
+	cases := []struct {
+		method, path, resource, operation string
+	}{
+		{http.MethodGet, "/api/rest/v1/products?limit=10", "products", "list"},
+		{http.MethodGet, "/api/rest/v1/products/sku_1", "products", "get"},
+		{http.MethodPatch, "/api/rest/v1/products", "products", "upsert"},
+		{http.MethodPatch, "/api/rest/v1/families/shoes/variants", "families/variants", "upsert"},
+		{http.MethodGet, "/api/rest/v1/families/shoes/variants/shoes_size", "families/variants", "get"},
+		{http.MethodGet, "/api/rest/v1/media-files/a/b/c/file.jpg/download", "media-files", "download"},
+		{http.MethodPost, "/api/oauth/v1/token", "token", "create"},
+		{http.MethodPost, "/api/rest/v1/jobs/export/csv_product_export", "jobs/export", "create"},
+		{http.MethodGet, "/api/rest/v1/products-uuid/0e0d3c68-3b6a-4d3f-8e1a-5c7e8a7c2f01/quality-scores", "products-uuid/quality-scores", "get"},
+		{http.MethodGet, "/api/rest/v1/reference-entities/brand/records/acme", "reference-entities/records", "get"},
+		{http.MethodGet, "/api/rest/v1/catalogs/7f4e/mapping-schemas/product", "catalogs/mapping-schemas/product", "get"},
+		{http.MethodGet, "/api/rest/v1/category-media-files/1/2/banner.jpg/download", "category-media-files", "download"},
+		{http.MethodPost, "/connect/apps/v1/oauth2/token", "oauth2/token", "create"},
+		{http.MethodGet, "/api/rest/v1/unknown/some_code", "other", "list"},
+	}
+	for _, c := range cases {
+		resource, operation := EndpointOf(c.method, c.path)
+		assert.Equal(t, c.resource, resource, c.path)
+		assert.Equal(t, c.operation, operation, c.path)
+	}
+}
+
+func TestWithHooks(t *testing.T) {
+	var requests []RequestEvent
+	var tokens []TokenEvent
+	var bulks []BulkEvent
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, PatchProductResponse{
+			{Line: 1, Identifier: "a", StatusCode: http.StatusNoContent},
+			{Line: 2, Identifier: "b", StatusCode: http.StatusUnprocessableEntity},
+		})
+	}, WithVersion(AkeneoPimVersion6), WithHooks(Hooks{
+		OnRequest:      func(e RequestEvent) { requests = append(requests, e) },
+		OnToken:        func(e TokenEvent) { tokens = append(tokens, e) },
+		OnBulkResponse: func(e BulkEvent) { bulks = append(bulks, e) },
+	}))
+	_, err := c.Product.UpdateOrCreateProducts([]Product{{Identifier: "a"}, {Identifier: "b"}})
+	assert.NoError(t, err)
+	assert.Len(t, tokens, 1)
+	assert.Equal(t, "password", tokens[0].GrantType)
+	if assert.NotEmpty(t, requests) {
+		last := requests[len(requests)-1]
+		assert.Equal(t, "products", last.Resource)
+		assert.Equal(t, "upsert", last.Operation)
+		assert.Equal(t, http.StatusOK, last.StatusCode)
+	}
+	assert.Equal(t, []BulkEvent{{Path: productBasePath, Resource: "products", Lines: 2, Failures: 1}}, bulks)
+}
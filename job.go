@@ -0,0 +1,64 @@
+package goakeneo
+
+import (
+	"path"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	jobBasePath = "/api/rest/v1/jobs"
+)
+
+// Job types
+const (
+	JobTypeImport = "import"
+	JobTypeExport = "export"
+)
+
+// JobService is the interface to launch Akeneo import and export job instances
+// waiting for the executions is out of scope until the API documents an endpoint returning their status,
+// follow them in the process tracker of the PIM
+type JobService interface {
+	LaunchExport(code string, options *JobLaunchOptions) (int, error)
+	LaunchImport(code string, options *JobLaunchOptions) (int, error)
+}
+
+type jobOp struct {
+	client *Client
+}
+
+// LaunchExport launches an export job instance by code, returns the execution id
+func (j *jobOp) LaunchExport(code string, options *JobLaunchOptions) (int, error) {
+	return j.launch(JobTypeExport, code, options)
+}
+
+// LaunchImport launches an import job instance by code, returns the execution id
+func (j *jobOp) LaunchImport(code string, options *JobLaunchOptions) (int, error) {
+	return j.launch(JobTypeImport, code, options)
+}
+
+func (j *jobOp) launch(jobType, code string, options *JobLaunchOptions) (int, error) {
+	if options == nil {
+		options = &JobLaunchOptions{}
+	}
+	sourcePath := path.Join(jobBasePath, jobType, code)
+	result := new(jobLaunchResponse)
+	if err := j.client.POST(sourcePath, nil, options, result); err != nil {
+		return 0, errors.Wrapf(err, "unable to launch %s job %s", jobType, code)
+	}
+	if result.ExecutionID == 0 {
+		return 0, errors.Errorf("no execution id in the response of %s job %s", jobType, code)
+	}
+	return result.ExecutionID, nil
+}
+
+// JobLaunchOptions is the body of a job launch request
+type JobLaunchOptions struct {
+	IsDryRun  bool `json:"is_dry_run,omitempty" mapstructure:"is_dry_run"`
+	SendEmail bool `json:"send_email,omitempty" mapstructure:"send_email"`
+}
+
+type jobLaunchResponse struct {
+	ExecutionID int `json:"execution_id,omitempty" mapstructure:"execution_id"`
+}
@@ -0,0 +1,45 @@
+package goakeneo
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobOp_LaunchExport(t *testing.T) {
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, jobBasePath+"/export/csv_product_export", r.URL.Path)
+		var options JobLaunchOptions
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&options))
+		assert.Equal(t, JobLaunchOptions{IsDryRun: true}, options)
+		writeJSON(w, http.StatusOK, map[string]any{"execution_id": 42})
+	}), WithVersion(AkeneoPimVersion7))
+	id, err := c.Job.LaunchExport("csv_product_export", &JobLaunchOptions{IsDryRun: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 42, id)
+}
+
+func TestJobOp_LaunchImport(t *testing.T) {
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case jobBasePath + "/import/csv_product_import":
+			writeJSON(w, http.StatusOK, map[string]any{"execution_id": 43})
+		case jobBasePath + "/import/no_execution":
+			w.WriteHeader(http.StatusOK)
+		default:
+			writeJSON(w, http.StatusNotFound, ErrorResponse{Code: http.StatusNotFound, Message: "Resource not found"})
+		}
+	}), WithVersion(AkeneoPimVersion7))
+	id, err := c.Job.LaunchImport("csv_product_import", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 43, id)
+
+	_, err = c.Job.LaunchImport("no_execution", nil)
+	assert.ErrorContains(t, err, "no execution id in the response of import job no_execution")
+
+	_, err = c.Job.LaunchImport("unknown", nil)
+	assert.ErrorContains(t, err, "unable to launch import job unknown")
+}
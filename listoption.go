@@ -1,5 +1,7 @@
 package goakeneo
 
+import "strings"
+
 // ListOptions is the struct for common list options
 type ListOptions struct {
 	Search    string `url:"search,omitempty"`
@@ -22,6 +24,13 @@ type ProductListOptions struct {
 	ListOptions
 }
 
+// SetScope filters the product values on a channel and its locales,
+// see LocaleService.ChannelLocales to resolve the locales of a channel
+func (o *ProductListOptions) SetScope(scope string, locales []string) {
+	o.Scope = scope
+	o.Locales = strings.Join(locales, ",")
+}
+
 // ProductModelListOptions specifies the product model optional parameters
 // see :https://api.akeneo.com/api-reference.html#Productmodel
 type ProductModelListOptions struct {
@@ -79,3 +88,49 @@ type CategoryListOptions struct {
 	WithPosition           bool `url:"with_position,omitempty"`
 	WithEnrichedAttributes bool `url:"with_enriched_attributes,omitempty"`
 }
+
+// ReferenceEntityListOptions specifies the reference entity optional parameters
+// see: https://api.akeneo.com/api-reference.html#get_reference_entities
+type ReferenceEntityListOptions struct {
+	SearchAfter string `url:"search_after,omitempty"`
+}
+
+// ReferenceEntityRecordListOptions specifies the reference entity record optional parameters
+// see: https://api.akeneo.com/api-reference.html#get_reference_entity_records
+type ReferenceEntityRecordListOptions struct {
+	Search      string `url:"search,omitempty"`
+	Channel     string `url:"channel,omitempty"`
+	Locales     string `url:"locales,omitempty"`
+	SearchAfter string `url:"search_after,omitempty"`
+}
+
+// AssetFamilyListOptions specifies the asset family optional parameters
+// see: https://api.akeneo.com/api-reference.html#get_asset_families
+type AssetFamilyListOptions struct {
+	SearchAfter string `url:"search_after,omitempty"`
+}
+
+// AssetListOptions specifies the asset optional parameters
+// see: https://api.akeneo.com/api-reference.html#get_assets
+type AssetListOptions struct {
+	Search      string `url:"search,omitempty"`
+	Channel     string `url:"channel,omitempty"`
+	Locales     string `url:"locales,omitempty"`
+	SearchAfter string `url:"search_after,omitempty"`
+}
+
+// CatalogListOptions specifies the catalog optional parameters
+// see: https://api.akeneo.com/api-reference.html#get_app_catalogs
+type CatalogListOptions struct {
+	Page  int `url:"page,omitempty"`
+	Limit int `url:"limit,omitempty"`
+}
+
+// CatalogProductListOptions specifies the catalog products, product uuids and mapped products optional parameters
+// UpdatedBefore and UpdatedAfter are ISO-8601 dates
+type CatalogProductListOptions struct {
+	Limit         int    `url:"limit,omitempty"`
+	SearchAfter   string `url:"search_after,omitempty"`
+	UpdatedBefore string `url:"updated_before,omitempty"`
+	UpdatedAfter  string `url:"updated_after,omitempty"`
+}
@@ -1,5 +1,11 @@
 package goakeneo
 
+import (
+	"path"
+
+	"github.com/pkg/errors"
+)
+
 const (
 	localeBasePath = "/api/rest/v1/locales"
 )
@@ -7,6 +13,9 @@ const (
 // LocaleService is the interface to interact with the Akeneo Locale API
 type LocaleService interface {
 	ListWithPagination(options any) ([]Locale, Links, error)
+	GetLocale(code string) (*Locale, error)
+	EnabledLocales() ([]Locale, error)
+	ChannelLocales(channelCode string) ([]string, error)
 }
 
 type localeOp struct {
@@ -27,6 +36,50 @@ func (c *localeOp) ListWithPagination(options any) ([]Locale, Links, error) {
 	return localeResponse.Embedded.Items, localeResponse.Links, nil
 }
 
+// GetLocale gets a locale by code
+func (c *localeOp) GetLocale(code string) (*Locale, error) {
+	sourcePath := path.Join(localeBasePath, code)
+	locale := new(Locale)
+	if err := c.client.GET(
+		sourcePath,
+		nil,
+		nil,
+		locale,
+	); err != nil {
+		return nil, err
+	}
+	return locale, nil
+}
+
+// EnabledLocales gets all the enabled locales, following the pagination
+func (c *localeOp) EnabledLocales() ([]Locale, error) {
+	sf := make(SearchFilter)
+	sf.Add("enabled", "=", true)
+	var result []Locale
+	locales, links, err := c.ListWithPagination(ListOptions{Search: sf.String(), Limit: 100})
+	for {
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to list enabled locales")
+		}
+		result = append(result, locales...)
+		if !links.HasNext() {
+			break
+		}
+		locales, links, err = c.ListWithPagination(links.NextOptions())
+	}
+	return result, nil
+}
+
+// ChannelLocales gets the codes of the locales activated for a channel,
+// they can be used with ProductListOptions.SetScope
+func (c *localeOp) ChannelLocales(channelCode string) ([]string, error) {
+	channel, err := c.client.Channel.GetChannel(channelCode)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get channel %s", channelCode)
+	}
+	return channel.Locales, nil
+}
+
 // LocalesResponse is the struct for a akeneo locales response
 type LocalesResponse struct {
 	Links       Links       `json:"_links" mapstructure:"_links"`
@@ -1,6 +1,9 @@
 package goakeneo
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -13,3 +16,60 @@ func TestLocaleOp_ListWithPagination(t *testing.T) {
 	assert.NotNil(t, locales)
 	assert.NotNil(t, pagi)
 }
+
+func TestLocaleOp_GetLocale(t *testing.T) {
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, localeBasePath+"/fr_FR", r.URL.Path)
+		writeJSON(w, http.StatusOK, Locale{Code: "fr_FR", Enabled: true})
+	}), WithVersion(AkeneoPimVersion6))
+	locale, err := c.Locale.GetLocale("fr_FR")
+	assert.NoError(t, err)
+	assert.Equal(t, &Locale{Code: "fr_FR", Enabled: true}, locale)
+}
+
+func TestLocaleOp_EnabledLocales(t *testing.T) {
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, localeBasePath, r.URL.Path)
+		resp := LocalesResponse{}
+		if r.URL.Query().Get("page") == "" {
+			var search SearchFilter
+			assert.NoError(t, json.Unmarshal([]byte(r.URL.Query().Get("search")), &search))
+			assert.Equal(t, "=", search["enabled"][0]["operator"])
+			assert.Equal(t, true, search["enabled"][0]["value"])
+			assert.Equal(t, "100", r.URL.Query().Get("limit"))
+			resp.Links.Next.Href = "http://" + r.Host + r.URL.Path + "?page=2&limit=100&search=" + url.QueryEscape(r.URL.Query().Get("search"))
+			resp.Embedded.Items = []Locale{{Code: "en_US", Enabled: true}}
+		} else {
+			assert.NotEmpty(t, r.URL.Query().Get("search"), "the filter is kept on the next pages")
+			resp.Embedded.Items = []Locale{{Code: "fr_FR", Enabled: true}}
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}), WithVersion(AkeneoPimVersion6))
+	locales, err := c.Locale.EnabledLocales()
+	assert.NoError(t, err)
+	assert.Equal(t, []Locale{{Code: "en_US", Enabled: true}, {Code: "fr_FR", Enabled: true}}, locales)
+}
+
+func TestLocaleOp_ChannelLocales(t *testing.T) {
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != channelBasePath+"/ecommerce" {
+			writeJSON(w, http.StatusNotFound, ErrorResponse{Code: http.StatusNotFound, Message: "Resource not found"})
+			return
+		}
+		writeJSON(w, http.StatusOK, Channel{Code: "ecommerce", Locales: []string{"en_US", "fr_FR"}})
+	}), WithVersion(AkeneoPimVersion6))
+	locales, err := c.Locale.ChannelLocales("ecommerce")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"en_US", "fr_FR"}, locales)
+	_, err = c.Locale.ChannelLocales("print")
+	assert.ErrorContains(t, err, "unable to get channel print")
+}
+
+func TestProductListOptions_SetScope(t *testing.T) {
+	var o ProductListOptions
+	o.SetScope("ecommerce", []string{"en_US", "fr_FR"})
+	v, err := structToURLValues(o)
+	assert.NoError(t, err)
+	assert.Equal(t, "ecommerce", v.Get("scope"))
+	assert.Equal(t, "en_US,fr_FR", v.Get("locales"))
+}
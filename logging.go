@@ -0,0 +1,159 @@
+package goakeneo
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const redacted = "REDACTED"
+
+// sensitiveHeaders are never logged in clear
+var sensitiveHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+	"Set-Cookie":    true,
+}
+
+// WithLogger logs the requests, retries and token events with the logger
+// successful requests are logged at debug level, failures at warn level
+// the credentials, tokens and request bodies are never logged
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// takeLimiter waits for the rate limiter and returns the time waited
+func (c *Client) takeLimiter() time.Duration {
+	start := time.Now()
+	c.limiter.Take()
+	return time.Since(start)
+}
+
+// logRequest logs a request once done, retries included
+func (c *Client) logRequest(method, rawURL string, resp *resty.Response, err error, wait, duration time.Duration) {
+	if c.logger == nil {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("path", redactedPath(rawURL)),
+		slog.Duration("duration", duration),
+		slog.Duration("rate_limit_wait", wait),
+	}
+	level := slog.LevelDebug
+	if resp != nil && resp.Request != nil {
+		attrs = append(attrs, slog.Int("attempts", resp.Request.Attempt))
+	}
+	if resp != nil && resp.RawResponse != nil {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode()))
+		if resp.IsError() {
+			level = slog.LevelWarn
+		}
+	}
+	if err != nil {
+		level = slog.LevelWarn
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	c.logger.LogAttrs(context.Background(), level, "akeneo request", attrs...)
+}
+
+// logRetry logs the retries of the requests, it is a resty retry hook
+func (c *Client) logRetry(resp *resty.Response, err error) {
+	if c.logger == nil {
+		return
+	}
+	attrs := make([]slog.Attr, 0, 5)
+	if resp != nil && resp.Request != nil {
+		attrs = append(attrs,
+			slog.String("method", resp.Request.Method),
+			slog.String("path", redactedPath(resp.Request.URL)),
+			slog.Int("attempt", resp.Request.Attempt),
+		)
+	}
+	if resp != nil && resp.RawResponse != nil {
+		attrs = append(attrs,
+			slog.Int("status", resp.StatusCode()),
+			slog.String("retry_after", resp.Header().Get("Retry-After")),
+		)
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	c.logger.LogAttrs(context.Background(), slog.LevelWarn, "akeneo request retry", attrs...)
+}
+
+// logToken logs a token event, i.e. a token granted or a refresh failure
+func (c *Client) logToken(msg, grantType string, err error) {
+	if c.logger == nil {
+		return
+	}
+	if err != nil {
+		c.logger.LogAttrs(context.Background(), slog.LevelWarn, msg,
+			slog.String("grant_type", grantType),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+	c.logger.LogAttrs(context.Background(), slog.LevelInfo, msg,
+		slog.String("grant_type", grantType),
+		slog.Time("expires_at", c.tokenExpiration()),
+	)
+}
+
+// loggingMiddleware logs every http attempt with the redacted headers at debug level
+func loggingMiddleware(logger *slog.Logger) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			attrs := []slog.Attr{
+				slog.String("method", req.Method),
+				slog.String("path", redactedPath(req.URL.String())),
+				slog.Duration("duration", time.Since(start)),
+				slog.Any("headers", redactedHeader(req.Header)),
+			}
+			if resp != nil {
+				attrs = append(attrs, slog.Int("status", resp.StatusCode))
+			}
+			if err != nil {
+				attrs = append(attrs, slog.String("error", err.Error()))
+			}
+			logger.LogAttrs(req.Context(), slog.LevelDebug, "akeneo http attempt", attrs...)
+			return resp, err
+		})
+	}
+}
+
+// redactedHeader logs the headers with the sensitive values redacted
+type redactedHeader http.Header
+
+// LogValue implements slog.LogValuer
+func (h redactedHeader) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, len(h))
+	for key, values := range h {
+		if sensitiveHeaders[http.CanonicalHeaderKey(key)] {
+			attrs = append(attrs, slog.String(key, redacted))
+			continue
+		}
+		attrs = append(attrs, slog.Any(key, values))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// redactedPath returns the path and the query of the url, without the user info and the host
+func redactedPath(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	if u.RawQuery == "" {
+		return u.Path
+	}
+	return u.Path + "?" + u.RawQuery
+}
@@ -0,0 +1,28 @@
+package goakeneo
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusNotFound, ErrorResponse{Code: http.StatusNotFound, Message: "not found"})
+	}, WithLogger(logger), WithVersion(AkeneoPimVersion6))
+	_, err := c.Channel.GetChannel("ecommerce")
+	assert.Error(t, err)
+
+	logs := buf.String()
+	assert.Contains(t, logs, `"msg":"akeneo token granted","grant_type":"password"`)
+	assert.Contains(t, logs, `"msg":"akeneo request","method":"GET","path":"/api/rest/v1/channels/ecommerce"`)
+	assert.Contains(t, logs, `"status":404`)
+	assert.Contains(t, logs, `"Authorization":"REDACTED"`)
+	assert.NotContains(t, logs, "access_token")
+	assert.NotContains(t, logs, "password\":")
+}
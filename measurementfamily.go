@@ -0,0 +1,263 @@
+package goakeneo
+
+import (
+	"encoding/json"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	measurementFamilyBasePath = "/api/rest/v1/measurement-families"
+	// maxConversionDecimals is the number of decimals of the conversions without an exact decimal result
+	maxConversionDecimals = 12
+)
+
+// MeasurementFamilyService is the interface to interact with the Akeneo Measurement Family API
+type MeasurementFamilyService interface {
+	List() ([]MeasurementFamily, error)
+	UpsertMeasurementFamilies(families []MeasurementFamily) (PatchMeasurementFamilyResponse, error)
+}
+
+type measurementFamilyOp struct {
+	client *Client
+}
+
+// List lists all measurement families, the endpoint is not paginated
+func (m *measurementFamilyOp) List() ([]MeasurementFamily, error) {
+	var families []MeasurementFamily
+	if err := m.client.GET(
+		measurementFamilyBasePath,
+		nil,
+		nil,
+		&families,
+	); err != nil {
+		return nil, err
+	}
+	return families, nil
+}
+
+// UpsertMeasurementFamilies updates or creates several measurement families with their units at once
+func (m *measurementFamilyOp) UpsertMeasurementFamilies(families []MeasurementFamily) (PatchMeasurementFamilyResponse, error) {
+	result := new(PatchMeasurementFamilyResponse)
+	if err := m.client.PATCH(
+		measurementFamilyBasePath,
+		nil,
+		families,
+		result,
+	); err != nil {
+		return nil, err
+	}
+	return *result, nil
+}
+
+// PatchMeasurementFamilyResponseLine is the struct for a line of an akeneo measurement families patch response
+type PatchMeasurementFamilyResponseLine struct {
+	Code       string            `json:"code,omitempty" mapstructure:"code"`
+	StatusCode int               `json:"status_code,omitempty" mapstructure:"status_code"`
+	Message    string            `json:"message,omitempty" mapstructure:"message"`
+	Errors     []ValidationError `json:"errors,omitempty" mapstructure:"errors"`
+}
+
+type PatchMeasurementFamilyResponse []PatchMeasurementFamilyResponseLine
+
+// Convert converts an amount from a unit of the family to another unit of the family
+// the amount is a decimal string, the result is formatted with the minimal number of digits
+func (f MeasurementFamily) Convert(amount string, fromUnit, toUnit string) (string, error) {
+	value, ok := new(big.Rat).SetString(amount)
+	if !ok {
+		return "", errors.Errorf("invalid amount %q", amount)
+	}
+	from, ok := f.Units[fromUnit]
+	if !ok {
+		return "", errors.Errorf("unit %s does not belong to measurement family %s", fromUnit, f.Code)
+	}
+	to, ok := f.Units[toUnit]
+	if !ok {
+		return "", errors.Errorf("unit %s does not belong to measurement family %s", toUnit, f.Code)
+	}
+	if fromUnit != toUnit {
+		// convert to the standard unit, then from the standard unit to the target unit
+		for _, op := range from.ConvertFromStandard {
+			if err := op.apply(value, false); err != nil {
+				return "", errors.Wrapf(err, "unable to convert from unit %s", fromUnit)
+			}
+		}
+		for i := len(to.ConvertFromStandard) - 1; i >= 0; i-- {
+			if err := to.ConvertFromStandard[i].apply(value, true); err != nil {
+				return "", errors.Wrapf(err, "unable to convert to unit %s", toUnit)
+			}
+		}
+	}
+	return formatRat(value), nil
+}
+
+// formatRat formats a number as a decimal string without trailing zeros,
+// exactly when it has a finite decimal expansion, rounded to maxConversionDecimals digits otherwise
+func formatRat(r *big.Rat) string {
+	decimals := maxConversionDecimals
+	if exact, ok := exactDecimals(r.Denom()); ok && exact < decimals {
+		decimals = exact
+	}
+	s := r.FloatString(decimals)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+// exactDecimals returns the number of decimals of the fractions with this denominator,
+// false when their decimal expansion is infinite, i.e. the denominator has a factor other than 2 and 5
+func exactDecimals(denom *big.Int) (int, bool) {
+	d := new(big.Int).Set(denom)
+	two, five := big.NewInt(2), big.NewInt(5)
+	var twos, fives int
+	mod := new(big.Int)
+	for d.Cmp(big.NewInt(1)) > 0 {
+		switch {
+		case mod.Mod(d, two).Sign() == 0:
+			d.Quo(d, two)
+			twos++
+		case mod.Mod(d, five).Sign() == 0:
+			d.Quo(d, five)
+			fives++
+		default:
+			return 0, false
+		}
+	}
+	return max(twos, fives), true
+}
+
+// apply applies the operation to the value, or its inverse when reverse is true
+func (o MeasurementOperation) apply(value *big.Rat, reverse bool) error {
+	operand, ok := new(big.Rat).SetString(o.Value)
+	if !ok {
+		return errors.Errorf("invalid operation value %q", o.Value)
+	}
+	operator := o.Operator
+	if reverse {
+		switch operator {
+		case "mul":
+			operator = "div"
+		case "div":
+			operator = "mul"
+		case "add":
+			operator = "sub"
+		case "sub":
+			operator = "add"
+		}
+	}
+	switch operator {
+	case "mul":
+		value.Mul(value, operand)
+	case "div":
+		if operand.Sign() == 0 {
+			return errors.New("division by zero")
+		}
+		value.Quo(value, operand)
+	case "add":
+		value.Add(value, operand)
+	case "sub":
+		value.Sub(value, operand)
+	default:
+		return errors.Errorf("unknown operator %q", o.Operator)
+	}
+	return nil
+}
+
+// MeasurementConverter converts metric values using the measurement families definitions
+type MeasurementConverter struct {
+	families map[string]MeasurementFamily
+	units    map[string][]string // unit code to measurement family codes
+}
+
+// NewMeasurementConverter creates a converter from the measurement families,
+// i.e. the result of MeasurementFamilyService.List
+func NewMeasurementConverter(families []MeasurementFamily) *MeasurementConverter {
+	c := &MeasurementConverter{
+		families: make(map[string]MeasurementFamily, len(families)),
+		units:    make(map[string][]string),
+	}
+	for _, family := range families {
+		c.families[family.Code] = family
+		for unit := range family.Units {
+			c.units[unit] = append(c.units[unit], family.Code)
+		}
+	}
+	return c
+}
+
+// FamilyOf returns the measurement family of a unit
+func (c *MeasurementConverter) FamilyOf(unit string) (MeasurementFamily, error) {
+	codes := c.units[unit]
+	switch len(codes) {
+	case 0:
+		return MeasurementFamily{}, errors.Errorf("unknown unit %s", unit)
+	case 1:
+		return c.families[codes[0]], nil
+	default:
+		return MeasurementFamily{}, errors.Errorf("unit %s belongs to several measurement families %v", unit, codes)
+	}
+}
+
+// Convert converts an amount from a unit to another unit of the same measurement family
+func (c *MeasurementConverter) Convert(amount string, fromUnit, toUnit string) (string, error) {
+	family, err := c.FamilyOf(fromUnit)
+	if err != nil {
+		return "", err
+	}
+	return family.Convert(amount, fromUnit, toUnit)
+}
+
+// ConvertMetric converts a metric value to the unit, locale and scope are kept
+func (c *MeasurementConverter) ConvertMetric(v MetricValue, toUnit string) (MetricValue, error) {
+	amount, err := metricAmountString(v.Data.Amount)
+	if err != nil {
+		return MetricValue{}, err
+	}
+	converted, err := c.Convert(amount, v.Unit(), toUnit)
+	if err != nil {
+		return MetricValue{}, err
+	}
+	return MetricValue{
+		Locale: v.Locale,
+		Scope:  v.Scope,
+		Data: metric{
+			Amount: converted,
+			Unit:   toUnit,
+		},
+	}, nil
+}
+
+// ToStandard converts a metric value to the standard unit of its measurement family
+func (c *MeasurementConverter) ToStandard(v MetricValue) (MetricValue, error) {
+	family, err := c.FamilyOf(v.Unit())
+	if err != nil {
+		return MetricValue{}, err
+	}
+	return c.ConvertMetric(v, family.StandardUnitCode)
+}
+
+// metricAmountString returns the amount of a metric as a decimal string
+// the amount is a string when decimals are allowed, a number otherwise
+func metricAmountString(amount any) (string, error) {
+	switch a := amount.(type) {
+	case string:
+		return a, nil
+	case int:
+		return strconv.Itoa(a), nil
+	case int64:
+		return strconv.FormatInt(a, 10), nil
+	case float64:
+		return strconv.FormatFloat(a, 'f', -1, 64), nil
+	case json.Number:
+		return a.String(), nil
+	default:
+		return "", errors.Errorf("invalid metric amount %v", amount)
+	}
+}
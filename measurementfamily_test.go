@@ -0,0 +1,129 @@
+package goakeneo
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeasurementConverter(t *testing.T) {
+	c := NewMeasurementConverter([]MeasurementFamily{
+		{
+			Code:             "Length",
+			StandardUnitCode: "METER",
+			Units: map[string]MeasurementUnit{
+				"METER":      {Code: "METER", ConvertFromStandard: []MeasurementOperation{{Operator: "mul", Value: "1"}}},
+				"MILLIMETER": {Code: "MILLIMETER", ConvertFromStandard: []MeasurementOperation{{Operator: "mul", Value: "0.001"}}},
+				"INCH":       {Code: "INCH", ConvertFromStandard: []MeasurementOperation{{Operator: "mul", Value: "0.0254"}}},
+			},
+		},
+		{
+			Code:             "Temperature",
+			StandardUnitCode: "KELVIN",
+			Units: map[string]MeasurementUnit{
+				"KELVIN": {Code: "KELVIN", ConvertFromStandard: []MeasurementOperation{{Operator: "mul", Value: "1"}}},
+				"CELSIUS": {Code: "CELSIUS", ConvertFromStandard: []MeasurementOperation{
+					{Operator: "add", Value: "273.15"},
+				}},
+			},
+		},
+	})
+
+	amount, err := c.Convert("1500", "MILLIMETER", "METER")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5", amount)
+
+	amount, err = c.Convert("254", "MILLIMETER", "INCH")
+	assert.NoError(t, err)
+	assert.Equal(t, "10", amount)
+
+	amount, err = c.Convert("300", "KELVIN", "CELSIUS")
+	assert.NoError(t, err)
+	assert.Equal(t, "26.85", amount)
+
+	// exact results, without float artefacts
+	amount, err = c.Convert("0.3", "INCH", "MILLIMETER")
+	assert.NoError(t, err)
+	assert.Equal(t, "7.62", amount)
+
+	amount, err = c.Convert("123456789.123456789", "METER", "MILLIMETER")
+	assert.NoError(t, err)
+	assert.Equal(t, "123456789123.456789", amount)
+
+	// infinite decimal expansions are rounded
+	amount, err = c.Convert("1", "MILLIMETER", "INCH")
+	assert.NoError(t, err)
+	assert.Equal(t, "0.03937007874", amount)
+
+	_, err = c.Convert("1", "MILLIMETER", "CELSIUS")
+	assert.Error(t, err)
+
+	v, err := c.ToStandard(MetricValue{Data: metric{Amount: float64(25), Unit: "CELSIUS"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "KELVIN", v.Unit())
+	assert.Equal(t, "298.15", v.Amount())
+}
+
+func TestMeasurementFamilyOp_List(t *testing.T) {
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, measurementFamilyBasePath, r.URL.Path)
+		writeJSON(w, http.StatusOK, []MeasurementFamily{{
+			Code:             "Length",
+			StandardUnitCode: "METER",
+			Units: map[string]MeasurementUnit{
+				"METER":      {Code: "METER", ConvertFromStandard: []MeasurementOperation{{Operator: "mul", Value: "1"}}, Symbol: "m"},
+				"CENTIMETER": {Code: "CENTIMETER", ConvertFromStandard: []MeasurementOperation{{Operator: "mul", Value: "0.01"}}, Symbol: "cm"},
+			},
+		}})
+	}), WithVersion(AkeneoPimVersion6))
+
+	families, err := c.MeasurementFamily.List()
+	assert.NoError(t, err)
+	if assert.Len(t, families, 1) {
+		assert.Equal(t, "METER", families[0].StandardUnitCode)
+		amount, err := families[0].Convert("150", "CENTIMETER", "METER")
+		assert.NoError(t, err)
+		assert.Equal(t, "1.5", amount)
+	}
+}
+
+func TestMeasurementFamilyOp_UpsertMeasurementFamilies(t *testing.T) {
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, measurementFamilyBasePath, r.URL.Path)
+		// the measurement families are sent as a json array, the endpoint is not line delimited
+		assert.Equal(t, defaultContentType, r.Header.Get("Content-Type"))
+		var families []MeasurementFamily
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&families))
+		if len(families) == 0 {
+			writeJSON(w, http.StatusBadRequest, ErrorResponse{Code: http.StatusBadRequest, Message: "The list of measurement families is empty."})
+			return
+		}
+		assert.Equal(t, []string{"Length", "Weight"}, []string{families[0].Code, families[1].Code})
+		assert.Equal(t, "0.01", families[0].Units["CENTIMETER"].ConvertFromStandard[0].Value)
+		writeJSON(w, http.StatusOK, PatchMeasurementFamilyResponse{
+			{Code: "Length", StatusCode: http.StatusNoContent},
+			{Code: "Weight", StatusCode: http.StatusUnprocessableEntity, Message: "The measurement family has data that does not comply with the business rules.",
+				Errors: []ValidationError{{Property: "standard_unit_code", Message: "The standard unit code is required."}}},
+		})
+	}), WithVersion(AkeneoPimVersion6))
+
+	lines, err := c.MeasurementFamily.UpsertMeasurementFamilies([]MeasurementFamily{
+		{Code: "Length", StandardUnitCode: "METER", Units: map[string]MeasurementUnit{
+			"CENTIMETER": {Code: "CENTIMETER", ConvertFromStandard: []MeasurementOperation{{Operator: "mul", Value: "0.01"}}},
+		}},
+		{Code: "Weight"},
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, lines, 2) {
+		assert.Equal(t, http.StatusNoContent, lines[0].StatusCode)
+		assert.Equal(t, http.StatusUnprocessableEntity, lines[1].StatusCode)
+		assert.Equal(t, "standard_unit_code", lines[1].Errors[0].Property)
+	}
+
+	_, err = c.MeasurementFamily.UpsertMeasurementFamilies([]MeasurementFamily{})
+	assert.ErrorContains(t, err, "The list of measurement families is empty.")
+}
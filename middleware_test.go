@@ -0,0 +1,39 @@
+package goakeneo
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithMiddleware(t *testing.T) {
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name+" "+req.URL.Path)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "test", r.Header.Get("X-Test"))
+		writeJSON(w, http.StatusOK, SystemInfo{Version: "6.0.0", Edition: EditionCommunity})
+	}, WithMiddleware(record("outer"), record("inner"), func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("X-Test", "test")
+			return next.RoundTrip(req)
+		})
+	}))
+	assert.Equal(t, []string{
+		"outer /" + authBasePath,
+		"inner /" + authBasePath,
+		"outer " + systemInformationBasePath,
+		"inner " + systemInformationBasePath,
+	}, calls)
+
+	calls = nil
+	assert.NoError(t, c.Auth.GrantByRefreshToken())
+	assert.Equal(t, []string{"outer /" + authBasePath, "inner /" + authBasePath}, calls)
+}
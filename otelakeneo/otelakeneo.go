@@ -0,0 +1,221 @@
+// Package otelakeneo instruments the akeneo client with OpenTelemetry traces and metrics,
+// it is a separate module so that the client does not depend on OpenTelemetry
+//
+//	client, err := goakeneo.NewClient(connector, otelakeneo.Options()...)
+//
+// the service methods of the client take no context, so the spans are not linked to the trace of the caller:
+// every request attempt, retries included, is the root span of its own trace
+package otelakeneo
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	goakeneo "github.com/ezifyio/go-akeneo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/ezifyio/go-akeneo/otelakeneo"
+
+// attribute keys of the spans and the metrics
+const (
+	ResourceKey   = attribute.Key("akeneo.resource")
+	OperationKey  = attribute.Key("akeneo.operation")
+	StatusCodeKey = attribute.Key("http.response.status_code")
+	MethodKey     = attribute.Key("http.request.method")
+	GrantTypeKey  = attribute.Key("akeneo.grant_type")
+	ErrorKey      = attribute.Key("akeneo.error")
+)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagators    propagation.TextMapPropagator
+}
+
+// Option configures the instrumentation
+type Option func(*config)
+
+// WithTracerProvider sets the tracer provider, the global one is used by default
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tp
+	}
+}
+
+// WithMeterProvider sets the meter provider, the global one is used by default
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = mp
+	}
+}
+
+// WithPropagators sets the propagators injecting the span context in the requests, the global one is used by default
+func WithPropagators(p propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagators = p
+	}
+}
+
+func newConfig(opts []Option) *config {
+	c := &config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagators:    otel.GetTextMapPropagator(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Options returns the client options instrumenting the client, the middleware and the hooks
+func Options(opts ...Option) []goakeneo.Option {
+	return []goakeneo.Option{
+		goakeneo.WithMiddleware(Middleware(opts...)),
+		goakeneo.WithHooks(Hooks(opts...)),
+	}
+}
+
+// Middleware creates a span per request attempt and records the request count and latency,
+// the spans are root spans, see the package documentation
+func Middleware(opts ...Option) goakeneo.Middleware {
+	c := newConfig(opts)
+	tracer := c.tracerProvider.Tracer(instrumentationName)
+	meter := c.meterProvider.Meter(instrumentationName)
+	requests, err := meter.Int64Counter("akeneo.client.requests",
+		metric.WithDescription("number of requests sent to akeneo, retries included"),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	duration, err := meter.Float64Histogram("akeneo.client.request.duration",
+		metric.WithDescription("duration of the requests sent to akeneo"),
+		metric.WithUnit("s"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	return func(next http.RoundTripper) http.RoundTripper {
+		return goakeneo.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			resource, operation := goakeneo.EndpointOf(req.Method, req.URL.Path)
+			attrs := []attribute.KeyValue{
+				ResourceKey.String(resource),
+				OperationKey.String(operation),
+				MethodKey.String(req.Method),
+			}
+			ctx, span := tracer.Start(req.Context(), "akeneo "+operation+" "+resource,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attrs...),
+				trace.WithAttributes(attribute.String("url.path", req.URL.Path)))
+			defer span.End()
+			req = req.Clone(ctx)
+			c.propagators.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			elapsed := time.Since(start)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				attrs = append(attrs, ErrorKey.Bool(true))
+			} else {
+				span.SetAttributes(StatusCodeKey.Int(resp.StatusCode))
+				if resp.StatusCode >= http.StatusBadRequest {
+					span.SetStatus(codes.Error, strconv.Itoa(resp.StatusCode))
+				}
+				attrs = append(attrs, StatusCodeKey.Int(resp.StatusCode))
+			}
+			set := metric.WithAttributes(attrs...)
+			if requests != nil {
+				requests.Add(ctx, 1, set)
+			}
+			if duration != nil {
+				duration.Record(ctx, elapsed.Seconds(), set)
+			}
+			return resp, err
+		})
+	}
+}
+
+// Hooks records the retries, the rate limiter wait time, the token grants and the bulk upsert failures
+func Hooks(opts ...Option) goakeneo.Hooks {
+	c := newConfig(opts)
+	meter := c.meterProvider.Meter(instrumentationName)
+	retries, err := meter.Int64Counter("akeneo.client.retries",
+		metric.WithDescription("number of retried requests"),
+		metric.WithUnit("{retry}"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	wait, err := meter.Float64Histogram("akeneo.client.rate_limit.wait",
+		metric.WithDescription("time spent waiting for the rate limiter"),
+		metric.WithUnit("s"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	tokens, err := meter.Int64Counter("akeneo.client.token.grants",
+		metric.WithDescription("number of token grants, password and refresh"),
+		metric.WithUnit("{grant}"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	bulkLines, err := meter.Int64Counter("akeneo.client.bulk.lines",
+		metric.WithDescription("number of lines sent in bulk upserts"),
+		metric.WithUnit("{line}"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	bulkFailures, err := meter.Int64Counter("akeneo.client.bulk.failures",
+		metric.WithDescription("number of bulk upsert lines rejected by akeneo"),
+		metric.WithUnit("{line}"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	ctx := context.Background()
+	return goakeneo.Hooks{
+		OnRequest: func(e goakeneo.RequestEvent) {
+			if wait == nil {
+				return
+			}
+			wait.Record(ctx, e.RateLimitWait.Seconds(), metric.WithAttributes(
+				ResourceKey.String(e.Resource),
+				OperationKey.String(e.Operation),
+			))
+		},
+		OnRetry: func(e goakeneo.RetryEvent) {
+			if retries == nil {
+				return
+			}
+			retries.Add(ctx, 1, metric.WithAttributes(
+				ResourceKey.String(e.Resource),
+				OperationKey.String(e.Operation),
+				StatusCodeKey.Int(e.StatusCode),
+			))
+		},
+		OnToken: func(e goakeneo.TokenEvent) {
+			if tokens == nil {
+				return
+			}
+			tokens.Add(ctx, 1, metric.WithAttributes(
+				GrantTypeKey.String(e.GrantType),
+				ErrorKey.Bool(e.Err != nil),
+			))
+		},
+		OnBulkResponse: func(e goakeneo.BulkEvent) {
+			set := metric.WithAttributes(ResourceKey.String(e.Resource))
+			if bulkLines != nil {
+				bulkLines.Add(ctx, int64(e.Lines), set)
+			}
+			if bulkFailures != nil {
+				bulkFailures.Add(ctx, int64(e.Failures), set)
+			}
+		},
+	}
+}
@@ -0,0 +1,77 @@
+package otelakeneo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	goakeneo "github.com/ezifyio/go-akeneo"
+	"github.com/stretchr/testify/assert"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestInstrumentation(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/oauth/v1/token":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"access_token":  "access_token",
+				"refresh_token": "refresh_token",
+				"expires_in":    3600,
+				"token_type":    "bearer",
+			})
+		case "/api/rest/v1/products":
+			_ = json.NewEncoder(w).Encode([]map[string]any{
+				{"line": 1, "identifier": "a", "status_code": 204},
+				{"line": 2, "identifier": "b", "status_code": 422},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":404,"message":"not found"}`))
+		}
+	}))
+	defer srv.Close()
+
+	spans := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	con := goakeneo.Connector{ClientID: "client_id", Secret: "secret", UserName: "username", Password: "password"}
+	opts := append(Options(WithTracerProvider(tp), WithMeterProvider(mp)),
+		goakeneo.WithBaseURL(srv.URL), goakeneo.WithVersion(goakeneo.AkeneoPimVersion6))
+	c, err := goakeneo.NewClient(con, opts...)
+	assert.NoError(t, err)
+	_, err = c.Product.UpdateOrCreateProducts([]goakeneo.Product{{Identifier: "a"}, {Identifier: "b"}})
+	assert.NoError(t, err)
+
+	var names []string
+	for _, s := range spans.Ended() {
+		names = append(names, s.Name())
+	}
+	assert.Contains(t, names, "akeneo create token")
+	assert.Contains(t, names, "akeneo upsert products")
+
+	var rm metricdata.ResourceMetrics
+	assert.NoError(t, reader.Collect(context.Background(), &rm))
+	sums := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if data, ok := m.Data.(metricdata.Sum[int64]); ok {
+				for _, dp := range data.DataPoints {
+					sums[m.Name] += dp.Value
+				}
+			}
+		}
+	}
+	assert.Equal(t, int64(1), sums["akeneo.client.token.grants"])
+	assert.Equal(t, int64(2), sums["akeneo.client.bulk.lines"])
+	assert.Equal(t, int64(1), sums["akeneo.client.bulk.failures"])
+	assert.GreaterOrEqual(t, sums["akeneo.client.requests"], int64(2))
+}
@@ -1,7 +1,9 @@
 package goakeneo
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"path"
 
 	"github.com/pkg/errors"
@@ -19,10 +21,19 @@ type ProductService interface {
 	ListWithPagination(options any) ([]Product, Links, error)
 	GetProduct(id string, options any) (*Product, error)
 	UpdateOrCreateProducts(products []Product) (PatchProductResponse, error)
+	UpsertProductPatches(patches []ProductPatch) (PatchProductResponse, error)
+	UpdateProduct(id string, product Product) error
+	DeleteProduct(id string) error
+	GetDraft(id string) (*Product, error)
+	SubmitProposal(id string) error
+	GetQualityScores(id string) ([]QualityScore, error)
+	IDResolver() *ProductIDResolver
+	GetMany(ctx context.Context, ids []string, opts GetManyOptions) (GetManyResult[Product], error)
 }
 
 type productOp struct {
-	client *Client
+	client   *Client
+	resolver *ProductIDResolver
 }
 
 // GetAllProducts lists all products, returns a channel to iterate over products
@@ -61,16 +72,37 @@ func (p *productOp) GetAllProducts(ctx context.Context, options any) (<-chan Pro
 	return prodChan, errChan
 }
 
-// ListWithPagination lists products with pagination
-func (p *productOp) ListWithPagination(options any) ([]Product, Links, error) {
-	// products-uuid path since akeneo 7
-	basePath := productBasePath
+// basePath returns the products path matching the PIM version, products-uuid path since akeneo 7
+func (p *productOp) basePath() string {
 	if p.client.osVersion >= AkeneoPimVersion7 {
-		basePath = productUUIDBasePath
+		return productUUIDBasePath
+	}
+	return productBasePath
+}
+
+// productPath returns the path of a product, id can be the identifier or, since akeneo 7, the uuid
+// identifiers are resolved to uuids on the products-uuid path
+func (p *productOp) productPath(id string) (string, error) {
+	if p.client.osVersion < AkeneoPimVersion7 || IsUUID(id) {
+		return path.Join(p.basePath(), id), nil
 	}
+	uuid, err := p.resolver.UUIDOf(id)
+	if err != nil {
+		return "", err
+	}
+	return path.Join(productUUIDBasePath, uuid), nil
+}
+
+// IDResolver returns the identifier and uuid resolver of the products, since akeneo 7
+func (p *productOp) IDResolver() *ProductIDResolver {
+	return p.resolver
+}
+
+// ListWithPagination lists products with pagination
+func (p *productOp) ListWithPagination(options any) ([]Product, Links, error) {
 	productResponse := new(ProductsResponse)
 	if err := p.client.GET(
-		basePath,
+		p.basePath(),
 		options,
 		nil,
 		productResponse,
@@ -80,14 +112,12 @@ func (p *productOp) ListWithPagination(options any) ([]Product, Links, error) {
 	return productResponse.Embedded.Items, productResponse.Links, nil
 }
 
-// GetProduct gets a product by its identifier
+// GetProduct gets a product by its identifier, or its uuid since akeneo 7
 func (p *productOp) GetProduct(id string, options any) (*Product, error) {
-	// products-uuid path since akeneo 7
-	basePath := productBasePath
-	if p.client.osVersion >= AkeneoPimVersion7 {
-		basePath = productUUIDBasePath
+	sourcePath, err := p.productPath(id)
+	if err != nil {
+		return nil, err
 	}
-	sourcePath := path.Join(basePath, id)
 	product := new(Product)
 	if err := p.client.GET(
 		sourcePath,
@@ -97,6 +127,7 @@ func (p *productOp) GetProduct(id string, options any) (*Product, error) {
 	); err != nil {
 		return nil, err
 	}
+	p.resolver.Remember(*product)
 	return product, nil
 }
 
@@ -113,6 +144,108 @@ func (p *productOp) UpdateOrCreateProducts(products []Product) (PatchProductResp
 	return *result, nil
 }
 
+// UpsertProductPatches updates or creates several products from patches, i.e. from MinimalPatch,
+// the patches are sent one json object per line, returns the status of every product
+func (p *productOp) UpsertProductPatches(patches []ProductPatch) (PatchProductResponse, error) {
+	return p.client.patchCollection(productBasePath, patches)
+}
+
+// UpdateProduct updates a product by its identifier, or its uuid since akeneo 7
+// the product is created when the identifier does not exist
+func (p *productOp) UpdateProduct(id string, product Product) error {
+	sourcePath, err := p.productPath(id)
+	if errors.Is(err, ErrProductNotFound) {
+		// unknown identifier, the products path creates it
+		sourcePath = path.Join(productBasePath, id)
+	} else if err != nil {
+		return err
+	}
+	if err := p.client.PATCH(
+		sourcePath,
+		nil,
+		product,
+		nil,
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteProduct deletes a product by its identifier, or its uuid since akeneo 7
+func (p *productOp) DeleteProduct(id string) error {
+	sourcePath, err := p.productPath(id)
+	if err != nil {
+		return err
+	}
+	if err := p.client.DELETE(
+		sourcePath,
+		nil,
+		nil,
+		nil,
+	); err != nil {
+		return err
+	}
+	p.resolver.Forget(id)
+	return nil
+}
+
+// GetDraft gets the draft of a product by its identifier, Enterprise Edition only
+// the draft status is available in Product.Metadata["workflow_status"]
+func (p *productOp) GetDraft(id string) (*Product, error) {
+	sourcePath, err := p.productPath(id)
+	if err != nil {
+		return nil, err
+	}
+	sourcePath = path.Join(sourcePath, "draft")
+	product := new(Product)
+	if err := p.client.GET(
+		sourcePath,
+		nil,
+		nil,
+		product,
+	); err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+// SubmitProposal submits the draft of a product for approval, Enterprise Edition only
+func (p *productOp) SubmitProposal(id string) error {
+	sourcePath, err := p.productPath(id)
+	if err != nil {
+		return err
+	}
+	sourcePath = path.Join(sourcePath, "proposal")
+	if err := p.client.POST(
+		sourcePath,
+		nil,
+		struct{}{},
+		nil,
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetQualityScores gets the quality scores of a product by its identifier, or its uuid since akeneo 7
+func (p *productOp) GetQualityScores(id string) ([]QualityScore, error) {
+	sourcePath, err := p.productPath(id)
+	if err != nil {
+		return nil, err
+	}
+	sourcePath = path.Join(sourcePath, "quality-scores")
+	result := new(QualityScoresResponse)
+	if err := p.client.GET(
+		sourcePath,
+		nil,
+		nil,
+		result,
+	); err != nil {
+		return nil, err
+	}
+	return result.QualityScores, nil
+}
+
 // ProductsResponse is the struct for an akeneo products response
 type ProductsResponse struct {
 	Links       Links        `json:"_links,omitempty" mapstructure:"_links"`
@@ -133,3 +266,16 @@ type PatchProductResponseLine struct {
 }
 type PatchProductRequest []Product
 type PatchProductResponse []PatchProductResponseLine
+
+// decodeLines decodes the response of a bulk PATCH request, one json object per line
+func (r *PatchProductResponse) decodeLines(body []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	for decoder.More() {
+		var line PatchProductResponseLine
+		if err := decoder.Decode(&line); err != nil {
+			return errors.Wrap(err, "unable to decode the bulk response")
+		}
+		*r = append(*r, line)
+	}
+	return nil
+}
@@ -29,21 +29,41 @@ func TestProducts(t *testing.T) {
 }
 
 func TestProductOp_GetAllProducts(t *testing.T) {
-	c := MockDLClient()
+	// the cassette is hand-written, see newCassetteClient
+	c := newCassetteClient(t)
 	prodChan, errChan := c.Product.GetAllProducts(context.Background(), nil)
+	var errs []error
+	done := make(chan struct{})
 	go func() {
+		defer close(done)
 		for err := range errChan {
-			t.Error(err)
+			errs = append(errs, err)
 		}
 	}()
+	var products []Product
 	for p := range prodChan {
-		if p.Identifier == "code-9200-eprcg" {
-			if v, ok := p.Values["<spu>"]; ok {
-				t.Logf("key: %s, value: %v", "<spu>", v)
-			} else {
-				t.Errorf("does not have key: %s", "<spu>")
-			}
-		}
+		products = append(products, p)
+	}
+	<-done
+	assert.Empty(t, errs)
+	if !assert.Len(t, products, 3, "the products of both pages") {
+		return
+	}
+	assert.Equal(t, []string{"code-9100-abcde", "code-9150-fghij", "code-9200-eprcg"},
+		[]string{products[0].Identifier, products[1].Identifier, products[2].Identifier})
 
+	p := products[2]
+	assert.True(t, p.Enabled)
+	assert.Equal(t, "clothing", p.Family)
+	assert.Equal(t, []string{"dresses", "summer"}, p.Categories)
+	if assert.Contains(t, p.Values, "<spu>") {
+		assert.Equal(t, "9200", p.Values["<spu>"][0].Data)
+	}
+	if assert.Contains(t, p.Values, "name") {
+		assert.Equal(t, "en_US", *p.Values["name"][0].Locale)
+		assert.Equal(t, "Dress 9200", p.Values["name"][0].Data)
+	}
+	if assert.Contains(t, p.Values, "price") {
+		assert.Equal(t, []any{map[string]any{"amount": "69.90", "currency": "USD"}}, p.Values["price"][0].Data)
 	}
 }
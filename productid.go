@@ -0,0 +1,167 @@
+package goakeneo
+
+import (
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultProductIDCacheTTL = time.Hour
+	productIDBatchSize       = 100
+)
+
+// ErrProductNotFound is returned by the resolver when no product has the identifier or uuid
+var ErrProductNotFound = errors.New("product not found")
+
+var uuidRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// IsUUID returns true if the product id is a uuid rather than an identifier
+func IsUUID(id string) bool {
+	return uuidRegexp.MatchString(id)
+}
+
+// ProductIDResolver maps product identifiers to uuids and back, since akeneo 7
+// results are cached with a TTL, unknown products are not cached
+type ProductIDResolver struct {
+	service      ProductService
+	ttl          time.Duration
+	mu           sync.Mutex
+	byIdentifier map[string]productIDEntry
+	byUUID       map[string]productIDEntry
+}
+
+type productIDEntry struct {
+	uuid       string
+	identifier string
+	expiresAt  time.Time
+}
+
+// NewProductIDResolver creates a resolver searching the products with the service
+func NewProductIDResolver(service ProductService, ttl time.Duration) *ProductIDResolver {
+	if ttl <= 0 {
+		ttl = defaultProductIDCacheTTL
+	}
+	return &ProductIDResolver{
+		service:      service,
+		ttl:          ttl,
+		byIdentifier: make(map[string]productIDEntry),
+		byUUID:       make(map[string]productIDEntry),
+	}
+}
+
+// UUIDOf returns the uuid of a product identifier
+func (r *ProductIDResolver) UUIDOf(identifier string) (string, error) {
+	result, err := r.ResolveUUIDs([]string{identifier})
+	if err != nil {
+		return "", err
+	}
+	uuid, ok := result[identifier]
+	if !ok {
+		return "", errors.Wrapf(ErrProductNotFound, "product %s", identifier)
+	}
+	return uuid, nil
+}
+
+// IdentifierOf returns the identifier of a product uuid
+func (r *ProductIDResolver) IdentifierOf(uuid string) (string, error) {
+	result, err := r.ResolveIdentifiers([]string{uuid})
+	if err != nil {
+		return "", err
+	}
+	identifier, ok := result[uuid]
+	if !ok {
+		return "", errors.Wrapf(ErrProductNotFound, "product %s", uuid)
+	}
+	return identifier, nil
+}
+
+// ResolveUUIDs returns the uuids of the product identifiers, keyed by identifier
+// unknown identifiers are missing from the result
+func (r *ProductIDResolver) ResolveUUIDs(identifiers []string) (map[string]string, error) {
+	result := make(map[string]string, len(identifiers))
+	missing := r.lookup(identifiers, r.byIdentifier, func(e productIDEntry) string { return e.uuid }, result)
+	if err := r.search("identifier", missing); err != nil {
+		return nil, err
+	}
+	r.lookup(missing, r.byIdentifier, func(e productIDEntry) string { return e.uuid }, result)
+	return result, nil
+}
+
+// ResolveIdentifiers returns the identifiers of the product uuids, keyed by uuid
+// unknown uuids are missing from the result
+func (r *ProductIDResolver) ResolveIdentifiers(uuids []string) (map[string]string, error) {
+	result := make(map[string]string, len(uuids))
+	missing := r.lookup(uuids, r.byUUID, func(e productIDEntry) string { return e.identifier }, result)
+	if err := r.search("uuid", missing); err != nil {
+		return nil, err
+	}
+	r.lookup(missing, r.byUUID, func(e productIDEntry) string { return e.identifier }, result)
+	return result, nil
+}
+
+// Remember caches the ids of a product
+func (r *ProductIDResolver) Remember(p Product) {
+	if p.UUID == "" || p.Identifier == "" {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entry := productIDEntry{uuid: p.UUID, identifier: p.Identifier, expiresAt: time.Now().Add(r.ttl)}
+	r.byIdentifier[p.Identifier] = entry
+	r.byUUID[p.UUID] = entry
+}
+
+// Forget removes a product id, identifier or uuid, from the cache
+func (r *ProductIDResolver) Forget(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, index := range []map[string]productIDEntry{r.byIdentifier, r.byUUID} {
+		if entry, ok := index[id]; ok {
+			delete(r.byIdentifier, entry.identifier)
+			delete(r.byUUID, entry.uuid)
+		}
+	}
+}
+
+// lookup fills the result with the cached ids and returns the missing ones
+func (r *ProductIDResolver) lookup(ids []string, index map[string]productIDEntry, value func(productIDEntry) string, result map[string]string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	var missing []string
+	for _, id := range ids {
+		entry, ok := index[id]
+		if !ok || now.After(entry.expiresAt) {
+			missing = append(missing, id)
+			continue
+		}
+		result[id] = value(entry)
+	}
+	return missing
+}
+
+// search searches the products by batches with the IN operator on the filter and caches their ids
+func (r *ProductIDResolver) search(filter string, ids []string) error {
+	for start := 0; start < len(ids); start += productIDBatchSize {
+		end := start + productIDBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		sf := make(SearchFilter)
+		sf.Add(filter, "IN", ids[start:end])
+		options := ProductListOptions{}
+		options.Search = sf.String()
+		options.Limit = productIDBatchSize
+		products, _, err := r.service.ListWithPagination(options)
+		if err != nil {
+			return errors.Wrapf(err, "unable to search products by %s", filter)
+		}
+		for _, p := range products {
+			r.Remember(p)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package goakeneo
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProductOp_GetProductByIdentifier(t *testing.T) {
+	const uuid = "1fd20ad8-ef95-49d7-a581-fb9f8ac0c5ad"
+	searches := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case systemInformationBasePath:
+			writeJSON(w, http.StatusOK, SystemInfo{Version: "7.0.0", Edition: EditionCommunity})
+		case productUUIDBasePath:
+			searches++
+			var sf SearchFilter
+			assert.NoError(t, json.Unmarshal([]byte(r.URL.Query().Get("search")), &sf))
+			assert.Equal(t, "IN", sf["identifier"][0]["operator"])
+			writeJSON(w, http.StatusOK, ProductsResponse{Embedded: productItems{Items: []Product{
+				{UUID: uuid, Identifier: "1111111304"},
+			}}})
+		case productUUIDBasePath + "/" + uuid:
+			writeJSON(w, http.StatusOK, Product{UUID: uuid, Identifier: "1111111304", Family: "accessories"})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	p, err := c.Product.GetProduct("1111111304", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "accessories", p.Family)
+	_, err = c.Product.GetProduct("1111111304", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, searches)
+
+	identifier, err := c.Product.IDResolver().IdentifierOf(uuid)
+	assert.NoError(t, err)
+	assert.Equal(t, "1111111304", identifier)
+}
+
+func TestIsUUID(t *testing.T) {
+	assert.True(t, IsUUID("1fd20ad8-ef95-49d7-a581-fb9f8ac0c5ad"))
+	assert.False(t, IsUUID("1111111304"))
+}
+
+func TestProductOp_UpdateProductResolverErrors(t *testing.T) {
+	var patches []string
+	searchFails := true
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == productUUIDBasePath:
+			if searchFails {
+				writeJSON(w, http.StatusInternalServerError, ErrorResponse{Code: http.StatusInternalServerError, Message: "Internal error"})
+				return
+			}
+			writeJSON(w, http.StatusOK, ProductsResponse{})
+		case r.Method == http.MethodPatch:
+			patches = append(patches, r.URL.Path)
+			w.WriteHeader(http.StatusCreated)
+		default:
+			http.NotFound(w, r)
+		}
+	}), WithVersion(AkeneoPimVersion7))
+
+	err := c.Product.UpdateProduct("sku_1", Product{Identifier: "sku_1"})
+	assert.ErrorContains(t, err, "Internal error")
+	assert.Empty(t, patches, "the product is not created when the resolver fails")
+
+	searchFails = false
+	assert.NoError(t, c.Product.UpdateProduct("sku_1", Product{Identifier: "sku_1"}))
+	assert.Equal(t, []string{productBasePath + "/sku_1"}, patches, "an unknown identifier is created")
+
+	_, err = c.Product.IDResolver().UUIDOf("sku_1")
+	assert.ErrorIs(t, err, ErrProductNotFound)
+}
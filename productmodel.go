@@ -1,8 +1,10 @@
 package goakeneo
 
 import (
-	"github.com/pkg/errors"
+	"context"
 	"path"
+
+	"github.com/pkg/errors"
 )
 
 const (
@@ -13,6 +15,10 @@ type ProductModelService interface {
 	ListWithPagination(options any) ([]ProductModel, Links, error)
 	GetProductModel(code string, options any) (*ProductModel, error)
 	Crate(pm ProductModel) error
+	GetDraft(code string) (*ProductModel, error)
+	SubmitProposal(code string) error
+	GetQualityScores(code string) ([]QualityScore, error)
+	GetMany(ctx context.Context, codes []string, opts GetManyOptions) (GetManyResult[ProductModel], error)
 }
 
 type productModelOp struct {
@@ -64,6 +70,51 @@ func (p *productModelOp) GetProductModel(code string, options any) (*ProductMode
 	return productModel, nil
 }
 
+// GetDraft gets the draft of a product model by code, Enterprise Edition only
+// the draft status is available in ProductModel.Metadata["workflow_status"]
+func (p *productModelOp) GetDraft(code string) (*ProductModel, error) {
+	sourcePath := path.Join(productModelBasePath, code, "draft")
+	productModel := new(ProductModel)
+	if err := p.client.GET(
+		sourcePath,
+		nil,
+		nil,
+		productModel,
+	); err != nil {
+		return nil, err
+	}
+	return productModel, nil
+}
+
+// SubmitProposal submits the draft of a product model for approval, Enterprise Edition only
+func (p *productModelOp) SubmitProposal(code string) error {
+	sourcePath := path.Join(productModelBasePath, code, "proposal")
+	if err := p.client.POST(
+		sourcePath,
+		nil,
+		struct{}{},
+		nil,
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetQualityScores gets the quality scores of a product model by code
+func (p *productModelOp) GetQualityScores(code string) ([]QualityScore, error) {
+	sourcePath := path.Join(productModelBasePath, code, "quality-scores")
+	result := new(QualityScoresResponse)
+	if err := p.client.GET(
+		sourcePath,
+		nil,
+		nil,
+		result,
+	); err != nil {
+		return nil, err
+	}
+	return result.QualityScores, nil
+}
+
 // ProductModelsResponse is the struct for the response of the ListWithPagination function
 type ProductModelsResponse struct {
 	Links       Links             `json:"_links" mapstructure:"_links"`
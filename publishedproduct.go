@@ -0,0 +1,48 @@
+package goakeneo
+
+import "path"
+
+const (
+	publishedProductBasePath = "/api/rest/v1/published-products"
+)
+
+// PublishedProductService is the interface to interact with the Akeneo Published Product API
+// Enterprise Edition only, published products are read only
+type PublishedProductService interface {
+	ListWithPagination(options any) ([]Product, Links, error)
+	GetPublishedProduct(code string, options any) (*Product, error)
+}
+
+type publishedProductOp struct {
+	client *Client
+}
+
+// ListWithPagination lists published products with pagination
+// options should be ProductListOptions or url.Values
+func (p *publishedProductOp) ListWithPagination(options any) ([]Product, Links, error) {
+	productResponse := new(ProductsResponse)
+	if err := p.client.GET(
+		publishedProductBasePath,
+		options,
+		nil,
+		productResponse,
+	); err != nil {
+		return nil, Links{}, err
+	}
+	return productResponse.Embedded.Items, productResponse.Links, nil
+}
+
+// GetPublishedProduct gets a published product by its identifier
+func (p *publishedProductOp) GetPublishedProduct(code string, options any) (*Product, error) {
+	sourcePath := path.Join(publishedProductBasePath, code)
+	product := new(Product)
+	if err := p.client.GET(
+		sourcePath,
+		options,
+		nil,
+		product,
+	); err != nil {
+		return nil, err
+	}
+	return product, nil
+}
@@ -0,0 +1,81 @@
+package goakeneo
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// notEnterprise answers like a Community Edition PIM, which has no route for the Enterprise Edition endpoints
+func notEnterprise(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusNotFound, ErrorResponse{Code: http.StatusNotFound, Message: "No route found for \"" + r.Method + " " + r.URL.Path + "\""})
+}
+
+func TestPublishedProductOp(t *testing.T) {
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case publishedProductBasePath:
+			assert.Equal(t, "ecommerce", r.URL.Query().Get("scope"))
+			resp := ProductsResponse{}
+			resp.Embedded.Items = []Product{{Identifier: "sku_1"}}
+			writeJSON(w, http.StatusOK, resp)
+		case publishedProductBasePath + "/sku_1":
+			writeJSON(w, http.StatusOK, Product{Identifier: "sku_1", Enabled: true})
+		default:
+			http.NotFound(w, r)
+		}
+	}), WithVersion(AkeneoPimVersion6))
+	products, _, err := c.PublishedProduct.ListWithPagination(ProductListOptions{Scope: "ecommerce"})
+	assert.NoError(t, err)
+	assert.Equal(t, []Product{{Identifier: "sku_1"}}, products)
+	product, err := c.PublishedProduct.GetPublishedProduct("sku_1", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &Product{Identifier: "sku_1", Enabled: true}, product)
+}
+
+func TestPublishedProductOp_NotEnterprise(t *testing.T) {
+	c := newTestClient(t, apiOnly(notEnterprise), WithVersion(AkeneoPimVersion6))
+	_, _, err := c.PublishedProduct.ListWithPagination(nil)
+	assert.ErrorContains(t, err, "No route found")
+	_, err = c.PublishedProduct.GetPublishedProduct("sku_1", nil)
+	assert.ErrorContains(t, err, "No route found")
+}
+
+func TestDrafts(t *testing.T) {
+	var proposals []string
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == productBasePath+"/sku_1/draft":
+			writeJSON(w, http.StatusOK, Product{Identifier: "sku_1", Metadata: map[string]string{"workflow_status": "draft_in_progress"}})
+		case r.Method == http.MethodGet && r.URL.Path == productModelBasePath+"/model_1/draft":
+			writeJSON(w, http.StatusOK, ProductModel{Code: "model_1", Metadata: map[string]string{"workflow_status": "draft_in_progress"}})
+		case r.Method == http.MethodPost && (r.URL.Path == productBasePath+"/sku_1/proposal" || r.URL.Path == productModelBasePath+"/model_1/proposal"):
+			proposals = append(proposals, r.URL.Path)
+			w.WriteHeader(http.StatusCreated)
+		default:
+			notEnterprise(w, r)
+		}
+	}), WithVersion(AkeneoPimVersion6))
+
+	draft, err := c.Product.GetDraft("sku_1")
+	assert.NoError(t, err)
+	assert.Equal(t, "draft_in_progress", draft.Metadata["workflow_status"])
+	modelDraft, err := c.ProductModel.GetDraft("model_1")
+	assert.NoError(t, err)
+	assert.Equal(t, "draft_in_progress", modelDraft.Metadata["workflow_status"])
+
+	assert.NoError(t, c.Product.SubmitProposal("sku_1"))
+	assert.NoError(t, c.ProductModel.SubmitProposal("model_1"))
+	assert.Equal(t, []string{productBasePath + "/sku_1/proposal", productModelBasePath + "/model_1/proposal"}, proposals)
+}
+
+func TestDrafts_NotEnterprise(t *testing.T) {
+	c := newTestClient(t, apiOnly(notEnterprise), WithVersion(AkeneoPimVersion6))
+	_, err := c.Product.GetDraft("sku_1")
+	assert.ErrorContains(t, err, "No route found")
+	assert.ErrorContains(t, c.Product.SubmitProposal("sku_1"), "No route found")
+	_, err = c.ProductModel.GetDraft("model_1")
+	assert.ErrorContains(t, err, "No route found")
+	assert.ErrorContains(t, c.ProductModel.SubmitProposal("model_1"), "No route found")
+}
@@ -0,0 +1,169 @@
+package goakeneo
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// QualityScoresResponse is the struct for an akeneo product or product model quality scores response
+type QualityScoresResponse struct {
+	UUID          string         `json:"uuid,omitempty" mapstructure:"uuid"`
+	Identifier    string         `json:"identifier,omitempty" mapstructure:"identifier"`
+	Code          string         `json:"code,omitempty" mapstructure:"code"`
+	QualityScores []QualityScore `json:"quality_scores,omitempty" mapstructure:"quality_scores"`
+}
+
+// CompletenessStats aggregates completeness percentages
+type CompletenessStats struct {
+	Count    int // number of products
+	Sum      int // sum of the percentages
+	Min      int
+	Max      int
+	Complete int // number of products with a 100% completeness
+}
+
+// Average returns the average completeness percentage
+func (s CompletenessStats) Average() float64 {
+	if s.Count == 0 {
+		return 0
+	}
+	return float64(s.Sum) / float64(s.Count)
+}
+
+func (s *CompletenessStats) add(percentage int) {
+	if s.Count == 0 || percentage < s.Min {
+		s.Min = percentage
+	}
+	if s.Count == 0 || percentage > s.Max {
+		s.Max = percentage
+	}
+	s.Count++
+	s.Sum += percentage
+	if percentage >= 100 {
+		s.Complete++
+	}
+}
+
+// CompletenessReport aggregates the completenesses of products per channel and per channel and locale
+// a product counts once per locale in the channel stats
+type CompletenessReport struct {
+	Products        int
+	ByChannel       map[string]*CompletenessStats
+	ByChannelLocale map[string]map[string]*CompletenessStats
+}
+
+// NewCompletenessReport creates an empty completeness report
+func NewCompletenessReport() *CompletenessReport {
+	return &CompletenessReport{
+		ByChannel:       make(map[string]*CompletenessStats),
+		ByChannelLocale: make(map[string]map[string]*CompletenessStats),
+	}
+}
+
+// Add adds the completenesses of a product to the report,
+// the product must have been fetched with ProductListOptions.WithCompleteness
+func (r *CompletenessReport) Add(p Product) {
+	r.Products++
+	for _, c := range p.Completenesses {
+		channel, ok := r.ByChannel[c.Scope]
+		if !ok {
+			channel = new(CompletenessStats)
+			r.ByChannel[c.Scope] = channel
+		}
+		channel.add(c.Data)
+		locales, ok := r.ByChannelLocale[c.Scope]
+		if !ok {
+			locales = make(map[string]*CompletenessStats)
+			r.ByChannelLocale[c.Scope] = locales
+		}
+		locale, ok := locales[c.Locale]
+		if !ok {
+			locale = new(CompletenessStats)
+			locales[c.Locale] = locale
+		}
+		locale.add(c.Data)
+	}
+}
+
+// AverageByChannel returns the average completeness percentage per channel
+func (r *CompletenessReport) AverageByChannel() map[string]float64 {
+	result := make(map[string]float64, len(r.ByChannel))
+	for channel, stats := range r.ByChannel {
+		result[channel] = stats.Average()
+	}
+	return result
+}
+
+// QualityScoreReport counts the quality score grades of products per channel and locale
+type QualityScoreReport struct {
+	Products int
+	Grades   map[string]map[string]map[string]int // channel, locale, grade ("A" to "E") to count
+}
+
+// NewQualityScoreReport creates an empty quality score report
+func NewQualityScoreReport() *QualityScoreReport {
+	return &QualityScoreReport{
+		Grades: make(map[string]map[string]map[string]int),
+	}
+}
+
+// Add adds the quality scores to the report
+func (r *QualityScoreReport) Add(scores []QualityScore) {
+	r.Products++
+	for _, s := range scores {
+		locales, ok := r.Grades[s.Scope]
+		if !ok {
+			locales = make(map[string]map[string]int)
+			r.Grades[s.Scope] = locales
+		}
+		grades, ok := locales[s.Locale]
+		if !ok {
+			grades = make(map[string]int)
+			locales[s.Locale] = grades
+		}
+		grades[s.Data]++
+	}
+}
+
+// Channels returns the channels of the report, sorted
+func (r *QualityScoreReport) Channels() []string {
+	channels := make([]string, 0, len(r.Grades))
+	for channel := range r.Grades {
+		channels = append(channels, channel)
+	}
+	sort.Strings(channels)
+	return channels
+}
+
+// BuildProductReports iterates over all the products matching the options,
+// and aggregates their completenesses and quality scores
+func BuildProductReports(ctx context.Context, service ProductService, options ProductListOptions) (*CompletenessReport, *QualityScoreReport, error) {
+	options.WithCompleteness = true
+	options.WithQualityScores = true
+	completeness := NewCompletenessReport()
+	quality := NewQualityScoreReport()
+	prodChan, errChan := service.GetAllProducts(ctx, options)
+	for prodChan != nil || errChan != nil {
+		select {
+		case p, ok := <-prodChan:
+			if !ok {
+				prodChan = nil
+				continue
+			}
+			completeness.Add(p)
+			quality.Add(p.QualityScores)
+		case err, ok := <-errChan:
+			if !ok {
+				errChan = nil
+				continue
+			}
+			return nil, nil, errors.Wrap(err, "unable to list products")
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	return completeness, quality, nil
+}
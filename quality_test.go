@@ -0,0 +1,141 @@
+package goakeneo
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompletenessReport(t *testing.T) {
+	r := NewCompletenessReport()
+	r.Add(Product{Completenesses: []Completeness{
+		{Scope: "ecommerce", Locale: "en_US", Data: 100},
+		{Scope: "ecommerce", Locale: "fr_FR", Data: 50},
+	}})
+	r.Add(Product{Completenesses: []Completeness{
+		{Scope: "ecommerce", Locale: "en_US", Data: 60},
+		{Scope: "mobile", Locale: "en_US", Data: 20},
+	}})
+	assert.Equal(t, 2, r.Products)
+	assert.Equal(t, map[string]float64{"ecommerce": 70, "mobile": 20}, r.AverageByChannel())
+	assert.Equal(t, 80.0, r.ByChannelLocale["ecommerce"]["en_US"].Average())
+	assert.Equal(t, 1, r.ByChannel["ecommerce"].Complete)
+	assert.Equal(t, 50, r.ByChannel["ecommerce"].Min)
+
+	q := NewQualityScoreReport()
+	q.Add([]QualityScore{{Scope: "ecommerce", Locale: "en_US", Data: "A"}})
+	q.Add([]QualityScore{{Scope: "ecommerce", Locale: "en_US", Data: "A"}, {Scope: "mobile", Locale: "en_US", Data: "C"}})
+	assert.Equal(t, 2, q.Grades["ecommerce"]["en_US"]["A"])
+	assert.Equal(t, []string{"ecommerce", "mobile"}, q.Channels())
+}
+
+func TestProductOp_GetQualityScores(t *testing.T) {
+	const uuid = "1fd20ad8-ef95-49d7-a581-fb9f8ac0c5ad"
+	scores := []QualityScore{{Scope: "ecommerce", Locale: "en_US", Data: "B"}}
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		switch r.URL.Path {
+		case productBasePath + "/sku_1/quality-scores":
+			writeJSON(w, http.StatusOK, QualityScoresResponse{Identifier: "sku_1", QualityScores: scores})
+		case productUUIDBasePath + "/" + uuid + "/quality-scores":
+			writeJSON(w, http.StatusOK, QualityScoresResponse{UUID: uuid, QualityScores: scores})
+		case productUUIDBasePath:
+			// the identifiers are resolved to uuids since akeneo 7
+			writeJSON(w, http.StatusOK, ProductsResponse{Embedded: productItems{Items: []Product{{UUID: uuid, Identifier: "sku_1"}}}})
+		default:
+			writeJSON(w, http.StatusNotFound, ErrorResponse{Code: http.StatusNotFound, Message: "Resource not found"})
+		}
+	}
+
+	c := newTestClient(t, apiOnly(handler), WithVersion(AkeneoPimVersion6))
+	got, err := c.Product.GetQualityScores("sku_1")
+	assert.NoError(t, err)
+	assert.Equal(t, scores, got)
+	_, err = c.Product.GetQualityScores("unknown")
+	assert.ErrorContains(t, err, "Resource not found")
+
+	c = newTestClient(t, apiOnly(handler), WithVersion(AkeneoPimVersion7))
+	got, err = c.Product.GetQualityScores(uuid)
+	assert.NoError(t, err)
+	assert.Equal(t, scores, got)
+	got, err = c.Product.GetQualityScores("sku_1")
+	assert.NoError(t, err, "the identifier is resolved to the uuid path")
+	assert.Equal(t, scores, got)
+}
+
+func TestProductModelOp_GetQualityScores(t *testing.T) {
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		switch r.URL.Path {
+		case productModelBasePath + "/model_1/quality-scores":
+			writeJSON(w, http.StatusOK, QualityScoresResponse{Code: "model_1", QualityScores: []QualityScore{
+				{Scope: "ecommerce", Locale: "en_US", Data: "A"},
+				{Scope: "ecommerce", Locale: "fr_FR", Data: "C"},
+			}})
+		default:
+			writeJSON(w, http.StatusNotFound, ErrorResponse{Code: http.StatusNotFound, Message: "Resource not found"})
+		}
+	}), WithVersion(AkeneoPimVersion7))
+	scores, err := c.ProductModel.GetQualityScores("model_1")
+	assert.NoError(t, err)
+	assert.Equal(t, []QualityScore{
+		{Scope: "ecommerce", Locale: "en_US", Data: "A"},
+		{Scope: "ecommerce", Locale: "fr_FR", Data: "C"},
+	}, scores)
+	_, err = c.ProductModel.GetQualityScores("unknown")
+	assert.ErrorContains(t, err, "Resource not found")
+}
+
+func TestBuildProductReports(t *testing.T) {
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, productBasePath, r.URL.Path)
+		query := r.URL.Query()
+		assert.Equal(t, "true", query.Get("with_completeness"))
+		assert.Equal(t, "true", query.Get("with_quality_scores"))
+		assert.Equal(t, "ecommerce", query.Get("scope"))
+		resp := ProductsResponse{}
+		switch query.Get("page") {
+		case "":
+			resp.Links.Next.Href = "http://" + r.Host + r.URL.Path + "?page=2&" + query.Encode()
+			resp.Embedded.Items = []Product{{
+				Identifier:     "sku_1",
+				Completenesses: []Completeness{{Scope: "ecommerce", Locale: "en_US", Data: 100}},
+				QualityScores:  []QualityScore{{Scope: "ecommerce", Locale: "en_US", Data: "A"}},
+			}}
+		case "2":
+			resp.Embedded.Items = []Product{{
+				Identifier:     "sku_2",
+				Completenesses: []Completeness{{Scope: "ecommerce", Locale: "en_US", Data: 50}},
+				QualityScores:  []QualityScore{{Scope: "ecommerce", Locale: "en_US", Data: "C"}},
+			}}
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}), WithVersion(AkeneoPimVersion6))
+
+	completeness, quality, err := BuildProductReports(context.Background(), c.Product, ProductListOptions{Scope: "ecommerce"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, completeness.Products)
+	assert.Equal(t, map[string]float64{"ecommerce": 75}, completeness.AverageByChannel())
+	assert.Equal(t, map[string]int{"A": 1, "C": 1}, quality.Grades["ecommerce"]["en_US"])
+}
+
+func TestBuildProductReports_Error(t *testing.T) {
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "" {
+			resp := ProductsResponse{}
+			resp.Links.Next.Href = "http://" + r.Host + r.URL.Path + "?page=2"
+			resp.Embedded.Items = []Product{{Identifier: "sku_1"}}
+			writeJSON(w, http.StatusOK, resp)
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Code: http.StatusInternalServerError, Message: "Internal error"})
+	}), WithVersion(AkeneoPimVersion6))
+
+	completeness, quality, err := BuildProductReports(context.Background(), c.Product, ProductListOptions{})
+	assert.ErrorContains(t, err, "unable to list products")
+	assert.ErrorContains(t, err, "Internal error")
+	assert.Nil(t, completeness)
+	assert.Nil(t, quality)
+}
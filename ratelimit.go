@@ -0,0 +1,183 @@
+package goakeneo
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"go.uber.org/ratelimit"
+)
+
+// FeedbackLimiter is a rate limiter adjusting its rate from the responses of the server,
+// the client calls OnResponse after every attempt, retries and token requests included
+type FeedbackLimiter interface {
+	ratelimit.Limiter
+	// OnResponse is called with the status code of the response and its Retry-After delay, 0 when missing
+	OnResponse(statusCode int, retryAfter time.Duration)
+}
+
+// AdaptiveLimiterConfig is the configuration of an AdaptiveLimiter, zero values are replaced by the defaults
+type AdaptiveLimiterConfig struct {
+	Initial  float64 // Initial is the starting rate in requests per second, default 5
+	Min      float64 // Min is the floor of the rate in requests per second, default 0.5
+	Max      float64 // Max is the ceiling of the rate in requests per second, default 20
+	Increase float64 // Increase is added to the rate on every successful response, default 0.1
+	Decrease float64 // Decrease multiplies the rate on every 429 response, default 0.5
+}
+
+// AdaptiveLimiter is an AIMD rate limiter, the rate increases additively on every success up to Max
+// and decreases multiplicatively on every 429 down to Min, a Retry-After header pauses all the requests.
+// it is safe for concurrent use, share one limiter between the clients of the same PIM with WithLimiter
+type AdaptiveLimiter struct {
+	mu          sync.Mutex
+	config      AdaptiveLimiterConfig
+	rate        float64
+	next        time.Time // next is the time of the next free slot
+	pausedUntil time.Time // pausedUntil is set by the Retry-After header
+	sleep       func(time.Duration)
+	now         func() time.Time
+}
+
+// NewAdaptiveLimiter creates an adaptive limiter
+func NewAdaptiveLimiter(config AdaptiveLimiterConfig) *AdaptiveLimiter {
+	if config.Max <= 0 {
+		config.Max = 20
+	}
+	if config.Min <= 0 {
+		config.Min = 0.5
+	}
+	if config.Min > config.Max {
+		config.Min = config.Max
+	}
+	if config.Initial <= 0 {
+		config.Initial = defaultRateLimit
+	}
+	if config.Initial > config.Max {
+		config.Initial = config.Max
+	}
+	if config.Initial < config.Min {
+		config.Initial = config.Min
+	}
+	if config.Increase <= 0 {
+		config.Increase = 0.1
+	}
+	if config.Decrease <= 0 || config.Decrease >= 1 {
+		config.Decrease = 0.5
+	}
+	return &AdaptiveLimiter{
+		config: config,
+		rate:   config.Initial,
+		sleep:  time.Sleep,
+		now:    time.Now,
+	}
+}
+
+// Take blocks until the next request is allowed and returns its time
+func (l *AdaptiveLimiter) Take() time.Time {
+	l.mu.Lock()
+	now := l.now()
+	slot := now
+	if l.next.After(slot) {
+		slot = l.next
+	}
+	if l.pausedUntil.After(slot) {
+		slot = l.pausedUntil
+	}
+	l.next = slot.Add(time.Duration(float64(time.Second) / l.rate))
+	l.mu.Unlock()
+	if wait := slot.Sub(now); wait > 0 {
+		l.sleep(wait)
+	}
+	return slot
+}
+
+// OnResponse adjusts the rate from a response
+func (l *AdaptiveLimiter) OnResponse(statusCode int, retryAfter time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if statusCode != http.StatusTooManyRequests {
+		if statusCode < http.StatusInternalServerError {
+			l.rate += l.config.Increase
+			if l.rate > l.config.Max {
+				l.rate = l.config.Max
+			}
+		}
+		return
+	}
+	l.rate *= l.config.Decrease
+	if l.rate < l.config.Min {
+		l.rate = l.config.Min
+	}
+	if retryAfter > 0 {
+		until := l.now().Add(retryAfter)
+		if until.After(l.pausedUntil) {
+			l.pausedUntil = until
+		}
+	}
+}
+
+// Rate returns the current rate in requests per second
+func (l *AdaptiveLimiter) Rate() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.rate
+}
+
+// WithLimiter sets the rate limiter of the client, a limiter can be shared by the clients of the same PIM,
+// a FeedbackLimiter such as AdaptiveLimiter is notified of every response
+func WithLimiter(limiter ratelimit.Limiter) Option {
+	return func(c *Client) {
+		c.limiter = limiter
+	}
+}
+
+// WithAdaptiveRateLimit sets an adaptive limiter increasing the rate toward max requests per second,
+// use NewAdaptiveLimiter and WithLimiter to tune it or share it
+func WithAdaptiveRateLimit(max float64) Option {
+	return func(c *Client) {
+		c.limiter = NewAdaptiveLimiter(AdaptiveLimiterConfig{Max: max})
+	}
+}
+
+// feedbackMiddleware notifies the limiter of every response
+func feedbackMiddleware(limiter FeedbackLimiter) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := next.RoundTrip(req)
+			if err == nil {
+				limiter.OnResponse(resp.StatusCode, parseRetryAfter(resp.Header.Get("Retry-After")))
+			}
+			return resp, err
+		})
+	}
+}
+
+// retryAfter is the resty retry after function, it waits for the Retry-After delay of the response
+// and falls back to the default backoff when missing
+func retryAfter(_ *resty.Client, resp *resty.Response) (time.Duration, error) {
+	if resp == nil {
+		return 0, nil
+	}
+	return parseRetryAfter(resp.Header().Get("Retry-After")), nil
+}
+
+// parseRetryAfter parses a Retry-After header, in seconds or as an http date, 0 when missing or invalid
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
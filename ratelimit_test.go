@@ -0,0 +1,69 @@
+package goakeneo
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdaptiveLimiter(t *testing.T) {
+	l := NewAdaptiveLimiter(AdaptiveLimiterConfig{Initial: 4, Min: 1, Max: 5, Increase: 0.5})
+	now := time.Unix(0, 0)
+	var slept time.Duration
+	l.now = func() time.Time { return now }
+	l.sleep = func(d time.Duration) { slept += d }
+
+	l.OnResponse(http.StatusOK, 0)
+	l.OnResponse(http.StatusOK, 0)
+	l.OnResponse(http.StatusOK, 0)
+	assert.Equal(t, 5.0, l.Rate(), "the rate stops at the ceiling")
+
+	l.OnResponse(http.StatusTooManyRequests, 0)
+	assert.Equal(t, 2.5, l.Rate())
+	l.OnResponse(http.StatusTooManyRequests, 0)
+	l.OnResponse(http.StatusTooManyRequests, 0)
+	assert.Equal(t, 1.0, l.Rate(), "the rate stops at the floor")
+
+	assert.Equal(t, now, l.Take())
+	assert.Equal(t, now.Add(time.Second), l.Take())
+	assert.Equal(t, time.Second, slept)
+
+	l.OnResponse(http.StatusTooManyRequests, 10*time.Second)
+	assert.Equal(t, now.Add(10*time.Second), l.Take(), "Retry-After pauses the requests")
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	assert.Equal(t, 3*time.Second, parseRetryAfter("3"))
+	assert.Equal(t, time.Duration(0), parseRetryAfter(""))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("soon"))
+	d := parseRetryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+	assert.True(t, d > 50*time.Second && d <= time.Minute)
+}
+
+func TestWithLimiter_Shared(t *testing.T) {
+	limiter := NewAdaptiveLimiter(AdaptiveLimiterConfig{Initial: 50, Min: 20, Max: 100})
+	throttle := false
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if throttle {
+			writeJSON(w, http.StatusTooManyRequests, ErrorResponse{Code: http.StatusTooManyRequests, Message: "Too many requests"})
+			return
+		}
+		writeJSON(w, http.StatusOK, Channel{Code: "ecommerce"})
+	}
+	c1 := newTestClient(t, handler, WithLimiter(limiter), WithRetry(0), WithVersion(AkeneoPimVersion6))
+	c2 := newTestClient(t, handler, WithLimiter(limiter), WithRetry(0), WithVersion(AkeneoPimVersion6))
+	throttle = true
+	rate := limiter.Rate()
+
+	_, err := c1.Channel.GetChannel("ecommerce")
+	assert.Error(t, err)
+	assert.Less(t, limiter.Rate(), rate, "a 429 of one client slows down the shared limiter")
+
+	throttle = false
+	rate = limiter.Rate()
+	_, err = c2.Channel.GetChannel("ecommerce")
+	assert.NoError(t, err)
+	assert.Greater(t, limiter.Rate(), rate)
+}
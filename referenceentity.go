@@ -0,0 +1,285 @@
+package goakeneo
+
+import (
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	referenceEntityBasePath          = "/api/rest/v1/reference-entities"
+	referenceEntityMediaFileBasePath = "/api/rest/v1/reference-entities-media-files"
+)
+
+// ReferenceEntityService is the interface to interact with the Akeneo Reference Entity API
+// Enterprise Edition only, see: https://api.akeneo.com/api-reference.html#Referenceentities
+type ReferenceEntityService interface {
+	ListWithPagination(options any) ([]ReferenceEntity, Links, error)
+	GetReferenceEntity(code string) (*ReferenceEntity, error)
+	UpdateOrCreateReferenceEntity(code string, entity ReferenceEntity) error
+	ListAttributes(entityCode string) ([]ReferenceEntityAttribute, error)
+	GetAttribute(entityCode, attributeCode string) (*ReferenceEntityAttribute, error)
+	UpdateOrCreateAttribute(entityCode, attributeCode string, attribute ReferenceEntityAttribute) error
+	ListAttributeOptions(entityCode, attributeCode string) ([]ReferenceEntityAttributeOption, error)
+	GetAttributeOption(entityCode, attributeCode, optionCode string) (*ReferenceEntityAttributeOption, error)
+	UpdateOrCreateAttributeOption(entityCode, attributeCode, optionCode string, option ReferenceEntityAttributeOption) error
+	ListRecordsWithPagination(entityCode string, options any) ([]ReferenceEntityRecord, Links, error)
+	GetRecord(entityCode, recordCode string) (*ReferenceEntityRecord, error)
+	UpdateOrCreateRecord(entityCode, recordCode string, record ReferenceEntityRecord) error
+	UpsertRecords(entityCode string, records []ReferenceEntityRecord) (PatchProductResponse, error)
+	UploadMediaFile(filePath string) (string, error)
+	DownloadMediaFile(code, filePath string) error
+}
+
+type referenceEntityOp struct {
+	client *Client
+}
+
+// ListWithPagination lists reference entities with pagination
+func (r *referenceEntityOp) ListWithPagination(options any) ([]ReferenceEntity, Links, error) {
+	response := new(ReferenceEntitiesResponse)
+	if err := r.client.GET(
+		referenceEntityBasePath,
+		options,
+		nil,
+		response,
+	); err != nil {
+		return nil, Links{}, err
+	}
+	return response.Embedded.Items, response.Links, nil
+}
+
+// GetReferenceEntity gets a reference entity by code
+func (r *referenceEntityOp) GetReferenceEntity(code string) (*ReferenceEntity, error) {
+	sourcePath := path.Join(referenceEntityBasePath, code)
+	entity := new(ReferenceEntity)
+	if err := r.client.GET(
+		sourcePath,
+		nil,
+		nil,
+		entity,
+	); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+// UpdateOrCreateReferenceEntity updates or creates a reference entity
+func (r *referenceEntityOp) UpdateOrCreateReferenceEntity(code string, entity ReferenceEntity) error {
+	sourcePath := path.Join(referenceEntityBasePath, code)
+	if err := r.client.PATCH(
+		sourcePath,
+		nil,
+		entity,
+		nil,
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ListAttributes lists the attributes of a reference entity, the endpoint is not paginated
+func (r *referenceEntityOp) ListAttributes(entityCode string) ([]ReferenceEntityAttribute, error) {
+	sourcePath := path.Join(referenceEntityBasePath, entityCode, "attributes")
+	var attributes []ReferenceEntityAttribute
+	if err := r.client.GET(
+		sourcePath,
+		nil,
+		nil,
+		&attributes,
+	); err != nil {
+		return nil, err
+	}
+	return attributes, nil
+}
+
+// GetAttribute gets a reference entity attribute by code
+func (r *referenceEntityOp) GetAttribute(entityCode, attributeCode string) (*ReferenceEntityAttribute, error) {
+	sourcePath := path.Join(referenceEntityBasePath, entityCode, "attributes", attributeCode)
+	attribute := new(ReferenceEntityAttribute)
+	if err := r.client.GET(
+		sourcePath,
+		nil,
+		nil,
+		attribute,
+	); err != nil {
+		return nil, err
+	}
+	return attribute, nil
+}
+
+// UpdateOrCreateAttribute updates or creates a reference entity attribute
+func (r *referenceEntityOp) UpdateOrCreateAttribute(entityCode, attributeCode string, attribute ReferenceEntityAttribute) error {
+	sourcePath := path.Join(referenceEntityBasePath, entityCode, "attributes", attributeCode)
+	if err := r.client.PATCH(
+		sourcePath,
+		nil,
+		attribute,
+		nil,
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ListAttributeOptions lists the options of a reference entity attribute, the endpoint is not paginated
+func (r *referenceEntityOp) ListAttributeOptions(entityCode, attributeCode string) ([]ReferenceEntityAttributeOption, error) {
+	sourcePath := path.Join(referenceEntityBasePath, entityCode, "attributes", attributeCode, "options")
+	var options []ReferenceEntityAttributeOption
+	if err := r.client.GET(
+		sourcePath,
+		nil,
+		nil,
+		&options,
+	); err != nil {
+		return nil, err
+	}
+	return options, nil
+}
+
+// GetAttributeOption gets a reference entity attribute option by code
+func (r *referenceEntityOp) GetAttributeOption(entityCode, attributeCode, optionCode string) (*ReferenceEntityAttributeOption, error) {
+	sourcePath := path.Join(referenceEntityBasePath, entityCode, "attributes", attributeCode, "options", optionCode)
+	option := new(ReferenceEntityAttributeOption)
+	if err := r.client.GET(
+		sourcePath,
+		nil,
+		nil,
+		option,
+	); err != nil {
+		return nil, err
+	}
+	return option, nil
+}
+
+// UpdateOrCreateAttributeOption updates or creates a reference entity attribute option
+func (r *referenceEntityOp) UpdateOrCreateAttributeOption(entityCode, attributeCode, optionCode string, option ReferenceEntityAttributeOption) error {
+	sourcePath := path.Join(referenceEntityBasePath, entityCode, "attributes", attributeCode, "options", optionCode)
+	if err := r.client.PATCH(
+		sourcePath,
+		nil,
+		option,
+		nil,
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ListRecordsWithPagination lists the records of a reference entity with search_after pagination
+// options should be ReferenceEntityRecordListOptions or url.Values
+func (r *referenceEntityOp) ListRecordsWithPagination(entityCode string, options any) ([]ReferenceEntityRecord, Links, error) {
+	sourcePath := path.Join(referenceEntityBasePath, entityCode, "records")
+	response := new(ReferenceEntityRecordsResponse)
+	if err := r.client.GET(
+		sourcePath,
+		options,
+		nil,
+		response,
+	); err != nil {
+		return nil, Links{}, err
+	}
+	return response.Embedded.Items, response.Links, nil
+}
+
+// GetRecord gets a reference entity record by code
+func (r *referenceEntityOp) GetRecord(entityCode, recordCode string) (*ReferenceEntityRecord, error) {
+	sourcePath := path.Join(referenceEntityBasePath, entityCode, "records", recordCode)
+	record := new(ReferenceEntityRecord)
+	if err := r.client.GET(
+		sourcePath,
+		nil,
+		nil,
+		record,
+	); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// UpdateOrCreateRecord updates or creates a reference entity record
+func (r *referenceEntityOp) UpdateOrCreateRecord(entityCode, recordCode string, record ReferenceEntityRecord) error {
+	sourcePath := path.Join(referenceEntityBasePath, entityCode, "records", recordCode)
+	if err := r.client.PATCH(
+		sourcePath,
+		nil,
+		record,
+		nil,
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpsertRecords updates or creates several records of a reference entity at once
+func (r *referenceEntityOp) UpsertRecords(entityCode string, records []ReferenceEntityRecord) (PatchProductResponse, error) {
+	sourcePath := path.Join(referenceEntityBasePath, entityCode, "records")
+	result := new(PatchProductResponse)
+	if err := r.client.PATCH(
+		sourcePath,
+		nil,
+		records,
+		result,
+	); err != nil {
+		return nil, err
+	}
+	return *result, nil
+}
+
+// UploadMediaFile uploads a reference entity media file, returns the code of the media file
+func (r *referenceEntityOp) UploadMediaFile(filePath string) (string, error) {
+	body, contentType, err := multipartFile(filePath, nil)
+	if err != nil {
+		return "", err
+	}
+	location, err := r.client.upload(referenceEntityMediaFileBasePath, body, contentType)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to upload file %s", filePath)
+	}
+	return mediaFileCodeFromLocation(location, referenceEntityMediaFileBasePath), nil
+}
+
+// DownloadMediaFile downloads a reference entity media file by code
+func (r *referenceEntityOp) DownloadMediaFile(code, filePath string) error {
+	sourcePath := path.Join(referenceEntityMediaFileBasePath, code)
+	sourceP, _ := url.Parse(sourcePath)
+	downloadURL := r.client.baseURL.ResolveReference(sourceP).String()
+	if err := r.client.download(downloadURL, filePath); err != nil {
+		return err
+	}
+	return nil
+}
+
+// mediaFileCodeFromLocation extracts the media file code from the location header of an upload
+func mediaFileCodeFromLocation(location, basePath string) string {
+	_, code, ok := strings.Cut(location, basePath+"/")
+	if !ok {
+		return location
+	}
+	if unescaped, err := url.PathUnescape(code); err == nil {
+		return unescaped
+	}
+	return code
+}
+
+// ReferenceEntitiesResponse is the struct for an akeneo reference entities response
+type ReferenceEntitiesResponse struct {
+	Links    Links                `json:"_links,omitempty" mapstructure:"_links"`
+	Embedded referenceEntityItems `json:"_embedded,omitempty" mapstructure:"_embedded"`
+}
+
+type referenceEntityItems struct {
+	Items []ReferenceEntity `json:"items,omitempty" mapstructure:"items"`
+}
+
+// ReferenceEntityRecordsResponse is the struct for an akeneo reference entity records response
+type ReferenceEntityRecordsResponse struct {
+	Links    Links                      `json:"_links,omitempty" mapstructure:"_links"`
+	Embedded referenceEntityRecordItems `json:"_embedded,omitempty" mapstructure:"_embedded"`
+}
+
+type referenceEntityRecordItems struct {
+	Items []ReferenceEntityRecord `json:"items,omitempty" mapstructure:"items"`
+}
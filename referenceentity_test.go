@@ -0,0 +1,242 @@
+package goakeneo
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReferenceEntityRecord_Value(t *testing.T) {
+	raw := `{
+		"code": "kartell",
+		"values": {
+			"label": [{"locale": "en_US", "channel": null, "data": "Kartell"}],
+			"year": [{"locale": null, "channel": null, "data": "1949"}],
+			"designers": [{"locale": null, "channel": null, "data": ["starck", "dixon"]}]
+		}
+	}`
+	var record ReferenceEntityRecord
+	assert.NoError(t, json.Unmarshal([]byte(raw), &record))
+
+	label, ok := record.Value("label", "", "en_US")
+	assert.True(t, ok)
+	text, ok := label.Text()
+	assert.True(t, ok)
+	assert.Equal(t, "Kartell", text)
+
+	year, ok := record.Value("year", "", "")
+	assert.True(t, ok)
+	n, err := year.Number()
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1949), n)
+
+	designers, ok := record.Value("designers", "", "")
+	assert.True(t, ok)
+	codes, ok := designers.Codes()
+	assert.True(t, ok)
+	assert.Equal(t, []string{"starck", "dixon"}, codes)
+
+	_, ok = record.Value("label", "", "fr_FR")
+	assert.False(t, ok)
+}
+
+func TestMediaFileCodeFromLocation(t *testing.T) {
+	code := mediaFileCodeFromLocation(
+		"https://demo.akeneo.com/api/rest/v1/reference-entities-media-files/0/2/d/6/54d81dc888ba1501a8g765f3ab5797569f3bv756c_ref_img.png",
+		referenceEntityMediaFileBasePath,
+	)
+	assert.Equal(t, "0/2/d/6/54d81dc888ba1501a8g765f3ab5797569f3bv756c_ref_img.png", code)
+}
+
+func TestReferenceEntityOp_Entities(t *testing.T) {
+	var patched ReferenceEntity
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == referenceEntityBasePath:
+			assert.Equal(t, "cursor", r.URL.Query().Get("search_after"))
+			resp := ReferenceEntitiesResponse{}
+			resp.Links.Next.Href = "http://" + r.Host + r.URL.Path + "?search_after=brand"
+			resp.Embedded.Items = []ReferenceEntity{{Code: "brand"}}
+			writeJSON(w, http.StatusOK, resp)
+		case r.Method == http.MethodGet && r.URL.Path == referenceEntityBasePath+"/brand":
+			writeJSON(w, http.StatusOK, ReferenceEntity{Code: "brand", Labels: map[string]string{"en_US": "Brand"}})
+		case r.Method == http.MethodPatch && r.URL.Path == referenceEntityBasePath+"/designer":
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&patched))
+			w.WriteHeader(http.StatusCreated)
+		default:
+			writeJSON(w, http.StatusNotFound, ErrorResponse{Code: http.StatusNotFound, Message: "Reference entity \"unknown\" does not exist."})
+		}
+	}), WithVersion(AkeneoPimVersion6))
+
+	entities, links, err := c.ReferenceEntity.ListWithPagination(url.Values{"search_after": {"cursor"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []ReferenceEntity{{Code: "brand"}}, entities)
+	assert.True(t, links.HasNext())
+
+	entity, err := c.ReferenceEntity.GetReferenceEntity("brand")
+	assert.NoError(t, err)
+	assert.Equal(t, "Brand", entity.Labels["en_US"])
+
+	assert.NoError(t, c.ReferenceEntity.UpdateOrCreateReferenceEntity("designer", ReferenceEntity{Code: "designer", Labels: map[string]string{"en_US": "Designer"}}))
+	assert.Equal(t, ReferenceEntity{Code: "designer", Labels: map[string]string{"en_US": "Designer"}}, patched)
+
+	_, err = c.ReferenceEntity.GetReferenceEntity("unknown")
+	assert.ErrorContains(t, err, "Reference entity \"unknown\" does not exist.")
+}
+
+func TestReferenceEntityOp_Attributes(t *testing.T) {
+	var requests []string
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		base := referenceEntityBasePath + "/brand/attributes"
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == base:
+			writeJSON(w, http.StatusOK, []ReferenceEntityAttribute{
+				{Code: "label", Type: ReferenceEntityAttributeTypeText},
+				{Code: "country", Type: ReferenceEntityAttributeTypeSingleOption},
+			})
+		case r.Method == http.MethodGet && r.URL.Path == base+"/country":
+			writeJSON(w, http.StatusOK, ReferenceEntityAttribute{Code: "country", Type: ReferenceEntityAttributeTypeSingleOption})
+		case r.Method == http.MethodGet && r.URL.Path == base+"/country/options":
+			writeJSON(w, http.StatusOK, []ReferenceEntityAttributeOption{{Code: "italy"}, {Code: "france"}})
+		case r.Method == http.MethodGet && r.URL.Path == base+"/country/options/italy":
+			writeJSON(w, http.StatusOK, ReferenceEntityAttributeOption{Code: "italy", Labels: map[string]string{"en_US": "Italy"}})
+		case r.Method == http.MethodPatch && r.URL.Path == base+"/country":
+			var attribute ReferenceEntityAttribute
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&attribute))
+			assert.Equal(t, ReferenceEntityAttributeTypeSingleOption, attribute.Type)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPatch && r.URL.Path == base+"/country/options/spain":
+			var option ReferenceEntityAttributeOption
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&option))
+			assert.Equal(t, "Spain", option.Labels["en_US"])
+			w.WriteHeader(http.StatusCreated)
+		default:
+			writeJSON(w, http.StatusNotFound, ErrorResponse{Code: http.StatusNotFound, Message: "Resource not found"})
+		}
+	}), WithVersion(AkeneoPimVersion6))
+
+	attributes, err := c.ReferenceEntity.ListAttributes("brand")
+	assert.NoError(t, err)
+	assert.Len(t, attributes, 2)
+	attribute, err := c.ReferenceEntity.GetAttribute("brand", "country")
+	assert.NoError(t, err)
+	assert.Equal(t, ReferenceEntityAttributeTypeSingleOption, attribute.Type)
+	assert.NoError(t, c.ReferenceEntity.UpdateOrCreateAttribute("brand", "country", *attribute))
+
+	options, err := c.ReferenceEntity.ListAttributeOptions("brand", "country")
+	assert.NoError(t, err)
+	assert.Equal(t, []ReferenceEntityAttributeOption{{Code: "italy"}, {Code: "france"}}, options)
+	option, err := c.ReferenceEntity.GetAttributeOption("brand", "country", "italy")
+	assert.NoError(t, err)
+	assert.Equal(t, "Italy", option.Labels["en_US"])
+	assert.NoError(t, c.ReferenceEntity.UpdateOrCreateAttributeOption("brand", "country", "spain",
+		ReferenceEntityAttributeOption{Code: "spain", Labels: map[string]string{"en_US": "Spain"}}))
+
+	_, err = c.ReferenceEntity.GetAttributeOption("brand", "country", "unknown")
+	assert.ErrorContains(t, err, "Resource not found")
+	assert.Len(t, requests, 7)
+}
+
+func TestReferenceEntityOp_Records(t *testing.T) {
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		base := referenceEntityBasePath + "/brand/records"
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == base:
+			assert.Equal(t, "kartell", r.URL.Query().Get("search_after"))
+			assert.Equal(t, "ecommerce", r.URL.Query().Get("channel"))
+			resp := ReferenceEntityRecordsResponse{}
+			resp.Embedded.Items = []ReferenceEntityRecord{{Code: "vitra"}}
+			writeJSON(w, http.StatusOK, resp)
+		case r.Method == http.MethodGet && r.URL.Path == base+"/kartell":
+			writeJSON(w, http.StatusOK, ReferenceEntityRecord{Code: "kartell", Values: map[string][]RecordValue{"label": {{Data: "Kartell"}}}})
+		case r.Method == http.MethodPatch && r.URL.Path == base+"/kartell":
+			var record ReferenceEntityRecord
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&record))
+			assert.Equal(t, "kartell", record.Code)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPatch && r.URL.Path == base:
+			// the records are sent as a json array, the bulk endpoint of the reference entities is not line delimited
+			var records []ReferenceEntityRecord
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&records))
+			if len(records) == 0 {
+				writeJSON(w, http.StatusBadRequest, ErrorResponse{Code: http.StatusBadRequest, Message: "Invalid json message received"})
+				return
+			}
+			assert.Equal(t, []ReferenceEntityRecord{{Code: "kartell"}, {Code: "vitra"}}, records)
+			writeJSON(w, http.StatusOK, PatchProductResponse{
+				{Code: "kartell", StatusCode: http.StatusNoContent},
+				{Code: "vitra", StatusCode: http.StatusUnprocessableEntity, Message: "The record has data that does not comply with the business rules."},
+			})
+		default:
+			writeJSON(w, http.StatusNotFound, ErrorResponse{Code: http.StatusNotFound, Message: "Resource not found"})
+		}
+	}), WithVersion(AkeneoPimVersion6))
+
+	records, links, err := c.ReferenceEntity.ListRecordsWithPagination("brand", ReferenceEntityRecordListOptions{SearchAfter: "kartell", Channel: "ecommerce"})
+	assert.NoError(t, err)
+	assert.Equal(t, []ReferenceEntityRecord{{Code: "vitra"}}, records)
+	assert.False(t, links.HasNext())
+
+	record, err := c.ReferenceEntity.GetRecord("brand", "kartell")
+	assert.NoError(t, err)
+	assert.Equal(t, "Kartell", record.Values["label"][0].Data)
+	assert.NoError(t, c.ReferenceEntity.UpdateOrCreateRecord("brand", "kartell", ReferenceEntityRecord{Code: "kartell"}))
+
+	lines, err := c.ReferenceEntity.UpsertRecords("brand", []ReferenceEntityRecord{{Code: "kartell"}, {Code: "vitra"}})
+	assert.NoError(t, err)
+	assert.Equal(t, PatchProductResponse{
+		{Code: "kartell", StatusCode: http.StatusNoContent},
+		{Code: "vitra", StatusCode: http.StatusUnprocessableEntity, Message: "The record has data that does not comply with the business rules."},
+	}, lines)
+	_, err = c.ReferenceEntity.UpsertRecords("brand", []ReferenceEntityRecord{})
+	assert.ErrorContains(t, err, "Invalid json message received")
+
+	_, err = c.ReferenceEntity.GetRecord("brand", "unknown")
+	assert.ErrorContains(t, err, "Resource not found")
+}
+
+func TestReferenceEntityOp_MediaFiles(t *testing.T) {
+	code := "0/2/d/6/54d81dc888ba1501a8g765f3ab5797569f3bv756c_ref_img.png"
+	c := newTestClient(t, apiOnly(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == referenceEntityMediaFileBasePath:
+			file, header, err := r.FormFile("file")
+			if assert.NoError(t, err) {
+				defer file.Close()
+				content, _ := io.ReadAll(file)
+				assert.Equal(t, "ref_img.png", header.Filename)
+				assert.Equal(t, "image", string(content))
+			}
+			w.Header().Set("Location", "http://"+r.Host+referenceEntityMediaFileBasePath+"/"+code)
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodGet && r.URL.Path == referenceEntityMediaFileBasePath+"/"+code:
+			_, _ = w.Write([]byte("image"))
+		default:
+			writeJSON(w, http.StatusNotFound, ErrorResponse{Code: http.StatusNotFound, Message: "Media file not found"})
+		}
+	}), WithVersion(AkeneoPimVersion6))
+
+	dir := t.TempDir()
+	source := filepath.Join(dir, "ref_img.png")
+	assert.NoError(t, os.WriteFile(source, []byte("image"), 0o644))
+	uploaded, err := c.ReferenceEntity.UploadMediaFile(source)
+	assert.NoError(t, err)
+	assert.Equal(t, code, uploaded)
+
+	target := filepath.Join(dir, "download", "ref_img.png")
+	assert.NoError(t, c.ReferenceEntity.DownloadMediaFile(code, target))
+	content, err := os.ReadFile(target)
+	assert.NoError(t, err)
+	assert.Equal(t, "image", string(content))
+
+	assert.ErrorContains(t, c.ReferenceEntity.DownloadMediaFile("unknown.png", filepath.Join(dir, "unknown.png")), "file not found")
+	_, err = c.ReferenceEntity.UploadMediaFile(filepath.Join(dir, "missing.png"))
+	assert.ErrorContains(t, err, "failed to open file")
+}
@@ -0,0 +1,275 @@
+package goakeneo
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Schema is a snapshot of the structure of the catalog: attributes with their options, families with their variants,
+// channels, locales, currencies and measurement families. it can be saved as json and used offline,
+// the indexes are built on the first lookup.
+// a Schema must be passed by pointer, go vet reports the copies, and must not be modified after the first lookup:
+// the indexes are built once and would not see the changes, load or decode a new Schema instead
+type Schema struct {
+	Attributes          []Attribute                  `json:"attributes" mapstructure:"attributes"`
+	AttributeOptions    map[string][]AttributeOption `json:"attribute_options" mapstructure:"attribute_options"` // options of the select attributes, by attribute code
+	Families            []Family                     `json:"families" mapstructure:"families"`
+	FamilyVariants      map[string][]FamilyVariant   `json:"family_variants" mapstructure:"family_variants"` // by family code
+	Channels            []Channel                    `json:"channels" mapstructure:"channels"`
+	Locales             []Locale                     `json:"locales" mapstructure:"locales"`
+	Currencies          []Currency                   `json:"currencies" mapstructure:"currencies"`
+	MeasurementFamilies []MeasurementFamily          `json:"measurement_families" mapstructure:"measurement_families"`
+	LoadedAt            time.Time                    `json:"loaded_at" mapstructure:"loaded_at"`
+
+	indexOnce  sync.Once // the indexes below are built once, from the exported fields at the first lookup
+	attributes map[string]*Attribute
+	options    map[string]map[string]*AttributeOption
+	families   map[string]*Family
+	variants   map[string]map[string]*FamilyVariant
+	channels   map[string]*Channel
+	locales    map[string]*Locale
+	currencies map[string]*Currency
+	metrics    map[string]*MeasurementFamily
+}
+
+// LoadSchema loads the structure of the catalog from all the structural services
+func LoadSchema(c *Client) (*Schema, error) {
+	s := &Schema{
+		AttributeOptions: make(map[string][]AttributeOption),
+		FamilyVariants:   make(map[string][]FamilyVariant),
+		LoadedAt:         time.Now(),
+	}
+	var err error
+	limit := ListOptions{Limit: 100}
+	if s.Attributes, err = listAll(c.Attribute.ListWithPagination, AttributeListOptions{ListOptions: limit}); err != nil {
+		return nil, errors.Wrap(err, "unable to load the attributes")
+	}
+	for _, attribute := range s.Attributes {
+		if attribute.Type != AttributeTypeSimpleSelect && attribute.Type != AttributeTypeMultiSelect {
+			continue
+		}
+		code := attribute.Code
+		options, err := listAll(func(options any) ([]AttributeOption, Links, error) {
+			return c.Attribute.GetAttributeOptions(code, options)
+		}, AttributeOptionListOptions{Limit: 100})
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to load the options of the attribute %s", code)
+		}
+		s.AttributeOptions[code] = options
+	}
+	if s.Families, err = listAll(c.Family.ListWithPagination, FamilyListOptions{ListOptions: limit}); err != nil {
+		return nil, errors.Wrap(err, "unable to load the families")
+	}
+	for _, family := range s.Families {
+		variants, err := c.Family.GetAllFamilyVariants(family.Code)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to load the variants of the family %s", family.Code)
+		}
+		if len(variants) > 0 {
+			s.FamilyVariants[family.Code] = variants
+		}
+	}
+	if s.Channels, err = listAll(c.Channel.ListWithPagination, limit); err != nil {
+		return nil, errors.Wrap(err, "unable to load the channels")
+	}
+	if s.Locales, err = listAll(c.Locale.ListWithPagination, limit); err != nil {
+		return nil, errors.Wrap(err, "unable to load the locales")
+	}
+	if s.Currencies, err = listAll(c.Currency.ListWithPagination, limit); err != nil {
+		return nil, errors.Wrap(err, "unable to load the currencies")
+	}
+	if s.MeasurementFamilies, err = c.MeasurementFamily.List(); err != nil {
+		return nil, errors.Wrap(err, "unable to load the measurement families")
+	}
+	return s, nil
+}
+
+// listAll lists all the pages of a paginated endpoint
+func listAll[T any](list func(options any) ([]T, Links, error), options any) ([]T, error) {
+	var all []T
+	items, links, err := list(options)
+	for {
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		if !links.HasNext() {
+			return all, nil
+		}
+		items, links, err = list(links.NextOptions())
+	}
+}
+
+// index builds the indexes once
+func (s *Schema) index() {
+	s.indexOnce.Do(func() {
+		s.attributes = make(map[string]*Attribute, len(s.Attributes))
+		for i := range s.Attributes {
+			s.attributes[s.Attributes[i].Code] = &s.Attributes[i]
+		}
+		s.options = make(map[string]map[string]*AttributeOption, len(s.AttributeOptions))
+		for attribute, options := range s.AttributeOptions {
+			byCode := make(map[string]*AttributeOption, len(options))
+			for i := range options {
+				byCode[options[i].Code] = &options[i]
+			}
+			s.options[attribute] = byCode
+		}
+		s.families = make(map[string]*Family, len(s.Families))
+		for i := range s.Families {
+			s.families[s.Families[i].Code] = &s.Families[i]
+		}
+		s.variants = make(map[string]map[string]*FamilyVariant, len(s.FamilyVariants))
+		for family, variants := range s.FamilyVariants {
+			byCode := make(map[string]*FamilyVariant, len(variants))
+			for i := range variants {
+				byCode[variants[i].Code] = &variants[i]
+			}
+			s.variants[family] = byCode
+		}
+		s.channels = make(map[string]*Channel, len(s.Channels))
+		for i := range s.Channels {
+			s.channels[s.Channels[i].Code] = &s.Channels[i]
+		}
+		s.locales = make(map[string]*Locale, len(s.Locales))
+		for i := range s.Locales {
+			s.locales[s.Locales[i].Code] = &s.Locales[i]
+		}
+		s.currencies = make(map[string]*Currency, len(s.Currencies))
+		for i := range s.Currencies {
+			s.currencies[s.Currencies[i].Code] = &s.Currencies[i]
+		}
+		s.metrics = make(map[string]*MeasurementFamily, len(s.MeasurementFamilies))
+		for i := range s.MeasurementFamilies {
+			s.metrics[s.MeasurementFamilies[i].Code] = &s.MeasurementFamilies[i]
+		}
+	})
+}
+
+// AttributeByCode returns an attribute by code
+func (s *Schema) AttributeByCode(code string) (*Attribute, bool) {
+	s.index()
+	a, ok := s.attributes[code]
+	return a, ok
+}
+
+// AttributeOption returns an option of a select attribute
+func (s *Schema) AttributeOption(attributeCode, optionCode string) (*AttributeOption, bool) {
+	s.index()
+	o, ok := s.options[attributeCode][optionCode]
+	return o, ok
+}
+
+// FamilyByCode returns a family by code
+func (s *Schema) FamilyByCode(code string) (*Family, bool) {
+	s.index()
+	f, ok := s.families[code]
+	return f, ok
+}
+
+// FamilyAttributes returns the attributes of a family, in the family order
+func (s *Schema) FamilyAttributes(family string) []Attribute {
+	s.index()
+	f, ok := s.families[family]
+	if !ok {
+		return nil
+	}
+	attributes := make([]Attribute, 0, len(f.Attributes))
+	for _, code := range f.Attributes {
+		if a, ok := s.attributes[code]; ok {
+			attributes = append(attributes, *a)
+		}
+	}
+	return attributes
+}
+
+// RequiredAttributes returns the codes of the attributes required by a family for the completeness of a channel
+func (s *Schema) RequiredAttributes(family, channel string) []string {
+	s.index()
+	f, ok := s.families[family]
+	if !ok {
+		return nil
+	}
+	return f.AttributeRequirements[channel]
+}
+
+// FamilyVariant returns a variant of a family
+func (s *Schema) FamilyVariant(family, variant string) (*FamilyVariant, bool) {
+	s.index()
+	v, ok := s.variants[family][variant]
+	return v, ok
+}
+
+// VariantLevelOf returns the level of an attribute in a family variant:
+// 0 for the attributes of the root product model, 1 or 2 for the attributes of the variant attribute sets.
+// false is returned when the family variant is unknown or the attribute is not in the family
+func (s *Schema) VariantLevelOf(family, variant, attribute string) (int, bool) {
+	s.index()
+	v, ok := s.variants[family][variant]
+	if !ok {
+		return 0, false
+	}
+	for _, set := range v.VariantAttributeSets {
+		for _, code := range set.Attributes {
+			if code == attribute {
+				return set.Level, true
+			}
+		}
+		for _, code := range set.Axes {
+			if code == attribute {
+				return set.Level, true
+			}
+		}
+	}
+	f, ok := s.families[family]
+	if !ok {
+		return 0, false
+	}
+	for _, code := range f.Attributes {
+		if code == attribute {
+			return 0, true
+		}
+	}
+	return 0, false
+}
+
+// ChannelByCode returns a channel by code
+func (s *Schema) ChannelByCode(code string) (*Channel, bool) {
+	s.index()
+	c, ok := s.channels[code]
+	return c, ok
+}
+
+// LocaleByCode returns a locale by code
+func (s *Schema) LocaleByCode(code string) (*Locale, bool) {
+	s.index()
+	l, ok := s.locales[code]
+	return l, ok
+}
+
+// EnabledLocales returns the codes of the enabled locales
+func (s *Schema) EnabledLocales() []string {
+	var codes []string
+	for _, l := range s.Locales {
+		if l.Enabled {
+			codes = append(codes, l.Code)
+		}
+	}
+	return codes
+}
+
+// CurrencyByCode returns a currency by code
+func (s *Schema) CurrencyByCode(code string) (*Currency, bool) {
+	s.index()
+	c, ok := s.currencies[code]
+	return c, ok
+}
+
+// MeasurementFamilyByCode returns a measurement family by code
+func (s *Schema) MeasurementFamilyByCode(code string) (*MeasurementFamily, bool) {
+	s.index()
+	m, ok := s.metrics[code]
+	return m, ok
+}
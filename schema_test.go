@@ -0,0 +1,85 @@
+package goakeneo
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// page writes a one page list response
+func page(w http.ResponseWriter, items any) {
+	writeJSON(w, http.StatusOK, map[string]any{"_links": map[string]any{}, "_embedded": map[string]any{"items": items}})
+}
+
+func testSchemaHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case attributeBasePath:
+		page(w, []Attribute{
+			{Code: "sku", Type: AttributeTypeIdentifier},
+			{Code: "name", Type: AttributeTypeText},
+			{Code: "color", Type: AttributeTypeSimpleSelect},
+			{Code: "size", Type: AttributeTypeSimpleSelect},
+			{Code: "weight", Type: AttributeTypeMetric},
+		})
+	case attributeBasePath + "/color/options":
+		page(w, []AttributeOption{{Code: "red", Attribute: "color"}, {Code: "blue", Attribute: "color"}})
+	case attributeBasePath + "/size/options":
+		page(w, []AttributeOption{{Code: "s", Attribute: "size"}, {Code: "m", Attribute: "size"}})
+	case familyBasePath:
+		page(w, []Family{{
+			Code:                  "shirts",
+			Attributes:            []string{"sku", "name", "color", "size", "weight"},
+			AttributeRequirements: map[string][]string{"ecommerce": {"sku", "name"}},
+		}})
+	case familyBasePath + "/shirts/variants":
+		page(w, []FamilyVariant{{
+			Code: "shirts_color_size",
+			VariantAttributeSets: []VariantAttributeSet{
+				{Level: 1, Axes: []string{"color"}, Attributes: []string{"color", "name"}},
+				{Level: 2, Axes: []string{"size"}, Attributes: []string{"size", "sku", "weight"}},
+			},
+		}})
+	case channelBasePath:
+		page(w, []Channel{{Code: "ecommerce", Locales: []string{"en_US"}, Currencies: []string{"EUR"}}})
+	case localeBasePath:
+		page(w, []Locale{{Code: "en_US", Enabled: true}, {Code: "fr_FR"}})
+	case currencyBasePath:
+		page(w, []Currency{{Code: "EUR", Enabled: true}})
+	case measurementFamilyBasePath:
+		writeJSON(w, http.StatusOK, []MeasurementFamily{{Code: "Weight", StandardUnitCode: "KILOGRAM"}})
+	default:
+		writeJSON(w, http.StatusNotFound, ErrorResponse{Code: http.StatusNotFound, Message: "Resource not found"})
+	}
+}
+
+func TestLoadSchema(t *testing.T) {
+	c := newTestClient(t, testSchemaHandler, WithVersion(AkeneoPimVersion6))
+	loaded, err := LoadSchema(c)
+	assert.NoError(t, err)
+
+	// the schema can be saved and used offline
+	data, err := json.Marshal(loaded)
+	assert.NoError(t, err)
+	s := new(Schema)
+	assert.NoError(t, json.Unmarshal(data, s))
+
+	a, ok := s.AttributeByCode("color")
+	assert.True(t, ok)
+	assert.Equal(t, AttributeTypeSimpleSelect, a.Type)
+	_, ok = s.AttributeOption("size", "m")
+	assert.True(t, ok)
+	_, ok = s.AttributeOption("size", "xl")
+	assert.False(t, ok)
+	assert.Len(t, s.FamilyAttributes("shirts"), 5)
+	assert.Equal(t, []string{"sku", "name"}, s.RequiredAttributes("shirts", "ecommerce"))
+	level, ok := s.VariantLevelOf("shirts", "shirts_color_size", "weight")
+	assert.True(t, ok)
+	assert.Equal(t, 2, level)
+	_, ok = s.VariantLevelOf("shirts", "shirts_color_size", "description")
+	assert.False(t, ok)
+	assert.Equal(t, []string{"en_US"}, s.EnabledLocales())
+	_, ok = s.MeasurementFamilyByCode("Weight")
+	assert.True(t, ok)
+}
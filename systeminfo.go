@@ -0,0 +1,100 @@
+package goakeneo
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	systemInformationBasePath = "/api/rest/v1/system-information"
+)
+
+// Akeneo PIM editions as returned by the system information endpoint
+const (
+	EditionCommunity  = "CE"
+	EditionEnterprise = "EE"
+	EditionSerenity   = "Serenity"
+	EditionGrowth     = "GE"
+	EditionFreeTrial  = "FT"
+)
+
+// SystemInfo is the struct for the akeneo system information
+type SystemInfo struct {
+	Version string `json:"version,omitempty" mapstructure:"version"` // i.e. "7.0.12", or a build number for SaaS editions, empty when unknown
+	Edition string `json:"edition,omitempty" mapstructure:"edition"` // empty when the PIM version has been probed
+}
+
+// IsSaaS returns true if the PIM is a SaaS edition, i.e. Serenity, Growth Edition or Free Trial
+func (s SystemInfo) IsSaaS() bool {
+	switch s.edition() {
+	case "serenity", "ge", "growth", "growthedition", "ft", "freetrial":
+		return true
+	default:
+		return false
+	}
+}
+
+// IsEnterprise returns true if the PIM provides the Enterprise Edition features, i.e. Enterprise Edition or Serenity
+func (s SystemInfo) IsEnterprise() bool {
+	switch s.edition() {
+	case "ee", "enterprise", "enterpriseedition", "serenity":
+		return true
+	default:
+		return false
+	}
+}
+
+// edition returns the edition lower cased without spaces, the editions are matched case insensitively
+func (s SystemInfo) edition() string {
+	return strings.ToLower(strings.ReplaceAll(s.Edition, " ", ""))
+}
+
+// PimVersion returns the PIM version as one of the AkeneoPimVersion constants,
+// SaaS editions are always up to date, 0 is returned when the version is unknown
+func (s SystemInfo) PimVersion() int {
+	if s.IsSaaS() {
+		return AkeneoPimVersion7
+	}
+	major, _, _ := strings.Cut(s.Version, ".")
+	v, err := strconv.Atoi(strings.TrimPrefix(strings.ToLower(major), "v"))
+	if err != nil {
+		return 0
+	}
+	if _, ok := pimVersionMap[v]; ok {
+		return v
+	}
+	if v > AkeneoPimVersion7 && v < 1000 {
+		return AkeneoPimVersion7
+	}
+	return 0
+}
+
+// SystemInfo returns the system information detected during the client init
+func (c *Client) SystemInfo() SystemInfo {
+	return c.systemInfo
+}
+
+// detectSystemInfo calls the system information endpoint, falling back to probing the products-uuid endpoint.
+// it still runs when the version is set with WithVersion, to know the edition, but the probe is skipped then
+// and the version set always wins over the detected one. the version of the SystemInfo is left empty when unknown
+func (c *Client) detectSystemInfo() error {
+	info := SystemInfo{}
+	err := c.GET(systemInformationBasePath, nil, nil, &info)
+	if (err != nil || info.PimVersion() == 0) && c.osVersion == 0 {
+		// the endpoint does not exist before 6.0, products-uuid exists since 7.0
+		info = SystemInfo{}
+		if probeErr := c.GET(productUUIDBasePath, ListOptions{Limit: 1}, nil, nil); probeErr == nil {
+			info.Version = pimVersionMap[AkeneoPimVersion7]
+		}
+	}
+	c.systemInfo = info
+	if c.osVersion == 0 {
+		c.osVersion = info.PimVersion()
+	}
+	if err != nil {
+		return errors.Wrap(err, "unable to get system information")
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package goakeneo
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_SystemInfo(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == systemInformationBasePath {
+			writeJSON(w, http.StatusOK, SystemInfo{Version: "7.0.12", Edition: EditionEnterprise})
+			return
+		}
+		http.NotFound(w, r)
+	})
+	assert.Equal(t, "7.0.12", c.SystemInfo().Version)
+	assert.True(t, c.SystemInfo().IsEnterprise())
+	assert.Equal(t, AkeneoPimVersion7, c.osVersion)
+	assert.Equal(t, productUUIDBasePath, c.Product.(*productOp).basePath())
+}
+
+func TestClient_SystemInfoProbing(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == productUUIDBasePath {
+			writeJSON(w, http.StatusOK, ProductsResponse{})
+			return
+		}
+		writeJSON(w, http.StatusNotFound, ErrorResponse{Code: http.StatusNotFound, Message: "not found"})
+	})
+	assert.Equal(t, AkeneoPimVersion7, c.osVersion)
+	assert.Empty(t, c.SystemInfo().Edition)
+}
+
+func TestClient_SystemInfoWithVersion(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, SystemInfo{Version: "20230621092302", Edition: EditionSerenity})
+	}, WithVersion(AkeneoPimVersion5))
+	assert.True(t, c.SystemInfo().IsSaaS())
+	assert.Equal(t, AkeneoPimVersion7, c.SystemInfo().PimVersion())
+	assert.Equal(t, AkeneoPimVersion5, c.osVersion)
+}
+
+func TestClient_SystemInfoUnknown(t *testing.T) {
+	var probes int
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == productUUIDBasePath {
+			probes++
+		}
+		writeJSON(w, http.StatusNotFound, ErrorResponse{Code: http.StatusNotFound, Message: "not found"})
+	}, WithVersion(AkeneoPimVersion6))
+	assert.Empty(t, c.SystemInfo().Version, "the version is not detected")
+	assert.Equal(t, 0, probes, "the version is not probed when set")
+	assert.Equal(t, AkeneoPimVersion6, c.osVersion)
+
+	c = newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusNotFound, ErrorResponse{Code: http.StatusNotFound, Message: "not found"})
+	})
+	assert.Empty(t, c.SystemInfo().Version)
+	assert.Equal(t, defaultVersion, c.osVersion)
+}
+
+func TestSystemInfo_Editions(t *testing.T) {
+	cases := []struct {
+		edition    string
+		enterprise bool
+		saas       bool
+	}{
+		{EditionEnterprise, true, false},
+		{"ee", true, false},
+		{"Enterprise Edition", true, false},
+		{EditionSerenity, true, true},
+		{"SERENITY", true, true},
+		{EditionGrowth, false, true},
+		{"Free Trial", false, true},
+		{EditionCommunity, false, false},
+		{"", false, false},
+	}
+	for _, c := range cases {
+		info := SystemInfo{Edition: c.edition}
+		assert.Equal(t, c.enterprise, info.IsEnterprise(), c.edition)
+		assert.Equal(t, c.saas, info.IsSaaS(), c.edition)
+	}
+}
@@ -1,7 +1,12 @@
 package goakeneo
 
 import (
+	"bytes"
+	"io"
+	"mime/multipart"
 	"net/url"
+	"os"
+	"path/filepath"
 
 	"github.com/google/go-querystring/query"
 	"github.com/pkg/errors"
@@ -15,3 +20,31 @@ func structToURLValues(s interface{}) (url.Values, error) {
 	}
 	return v, nil
 }
+
+// multipartFile builds a multipart/form-data body holding the file under the "file" field and the extra fields
+// returns the body and its content type
+func multipartFile(filePath string, fields map[string]string) ([]byte, string, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "failed to open file %s", filePath)
+	}
+	defer f.Close()
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	fileWriter, err := writer.CreateFormFile("file", filepath.Base(filePath))
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "failed to create form file %s", filePath)
+	}
+	if _, err = io.Copy(fileWriter, f); err != nil {
+		return nil, "", errors.Wrapf(err, "failed to copy file %s", filePath)
+	}
+	for key, value := range fields {
+		if err = writer.WriteField(key, value); err != nil {
+			return nil, "", errors.Wrapf(err, "failed to write field %s", key)
+		}
+	}
+	if err = writer.Close(); err != nil {
+		return nil, "", errors.Wrapf(err, "failed to close writer %s", filePath)
+	}
+	return buf.Bytes(), writer.FormDataContentType(), nil
+}
@@ -0,0 +1,407 @@
+package goakeneo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/mail"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Error returns the violation as a string
+func (e ValidationError) Error() string {
+	var where []string
+	if e.Attribute != "" {
+		where = append(where, "attribute "+e.Attribute)
+	}
+	if e.Locale != "" {
+		where = append(where, "locale "+e.Locale)
+	}
+	if e.Scope != "" {
+		where = append(where, "scope "+e.Scope)
+	}
+	if len(where) == 0 {
+		return fmt.Sprintf("%s: %s", e.Property, e.Message)
+	}
+	return fmt.Sprintf("%s (%s): %s", e.Property, strings.Join(where, ", "), e.Message)
+}
+
+// Validate checks a product against the schema before sending it and returns all the violations,
+// the checks are the family membership, the locale and scope of the values and the attribute constraints.
+// the PIM may still reject a valid product, i.e. for the unique values
+func Validate(product Product, schema *Schema) []ValidationError {
+	v := &productValidator{schema: schema}
+	var family *Family
+	if product.Family != "" {
+		var ok bool
+		if family, ok = schema.FamilyByCode(product.Family); !ok {
+			v.add(ValidationError{Property: "family", Message: fmt.Sprintf("the family %s does not exist", product.Family)})
+		}
+	}
+	for code, values := range product.Values {
+		attribute, ok := schema.AttributeByCode(code)
+		if !ok {
+			v.add(ValidationError{Property: "values", Attribute: code, Message: "the attribute does not exist"})
+			continue
+		}
+		if family != nil && !containsString(family.Attributes, code) {
+			v.add(ValidationError{Property: "values", Attribute: code, Message: fmt.Sprintf("the attribute does not belong to the family %s", family.Code)})
+		}
+		seen := make(map[string]bool, len(values))
+		for _, value := range values {
+			locale, scope := stringOf(value.Locale), stringOf(value.Scope)
+			key := locale + "|" + scope
+			if seen[key] {
+				v.add(ValidationError{Property: "values", Attribute: code, Locale: locale, Scope: scope, Message: "the value is duplicated"})
+				continue
+			}
+			seen[key] = true
+			v.validateValue(attribute, locale, scope, value.Data)
+		}
+	}
+	return v.errors
+}
+
+type productValidator struct {
+	schema *Schema
+	errors []ValidationError
+}
+
+func (v *productValidator) add(e ValidationError) {
+	v.errors = append(v.errors, e)
+}
+
+// validateValue checks the locale and the scope of a value, then its data
+func (v *productValidator) validateValue(a *Attribute, locale, scope string, data any) {
+	fail := func(format string, args ...any) {
+		v.add(ValidationError{Property: "values", Attribute: a.Code, Locale: locale, Scope: scope, Message: fmt.Sprintf(format, args...)})
+	}
+	localizable, scopable := a.Localizable != nil && *a.Localizable, a.Scopable != nil && *a.Scopable
+	switch {
+	case localizable && locale == "":
+		fail("the attribute is localizable, a locale is expected")
+	case !localizable && locale != "":
+		fail("the attribute is not localizable, no locale is expected")
+	case localizable:
+		if l, ok := v.schema.LocaleByCode(locale); !ok || !l.Enabled {
+			fail("the locale %s is not enabled", locale)
+		} else if len(a.AvailableLocales) > 0 && !containsString(a.AvailableLocales, locale) {
+			fail("the locale %s is not available for this locale specific attribute", locale)
+		}
+	}
+	switch {
+	case scopable && scope == "":
+		fail("the attribute is scopable, a scope is expected")
+	case !scopable && scope != "":
+		fail("the attribute is not scopable, no scope is expected")
+	case scopable:
+		if channel, ok := v.schema.ChannelByCode(scope); !ok {
+			fail("the channel %s does not exist", scope)
+		} else if localizable && locale != "" && !containsString(channel.Locales, locale) {
+			fail("the locale %s is not activated for the channel %s", locale, scope)
+		}
+	}
+	if data = normalizeData(data); data == nil {
+		// an empty value removes the value
+		return
+	}
+	switch a.Type {
+	case AttributeTypeText, AttributeTypeTextarea:
+		text, ok := data.(string)
+		if !ok {
+			fail("a string is expected")
+			return
+		}
+		if a.MaxCharacters != nil && *a.MaxCharacters > 0 && utf8.RuneCountInString(text) > *a.MaxCharacters {
+			fail("the text is longer than %d characters", *a.MaxCharacters)
+		}
+		if a.Type == AttributeTypeText {
+			if msg := validateTextRule(a, text); msg != "" {
+				fail("%s", msg)
+			}
+		}
+	case AttributeTypeNumber:
+		if msg := validateNumber(a, data); msg != "" {
+			fail("%s", msg)
+		}
+	case AttributeTypeMetric:
+		m, ok := data.(map[string]any)
+		if !ok {
+			fail("an amount and a unit are expected")
+			return
+		}
+		if msg := validateNumber(a, m["amount"]); msg != "" {
+			fail("%s", msg)
+		}
+		unit, _ := m["unit"].(string)
+		if a.MetricFamily != nil {
+			if family, ok := v.schema.MeasurementFamilyByCode(*a.MetricFamily); ok {
+				if _, ok := family.Units[unit]; !ok {
+					fail("the unit %s does not belong to the measurement family %s", unit, family.Code)
+				}
+			}
+		}
+	case AttributeTypePrice:
+		prices, ok := data.([]any)
+		if !ok {
+			fail("a list of prices is expected")
+			return
+		}
+		for _, p := range prices {
+			price, ok := p.(map[string]any)
+			if !ok {
+				fail("an amount and a currency are expected")
+				continue
+			}
+			currency, _ := price["currency"].(string)
+			if c, ok := v.schema.CurrencyByCode(currency); !ok || !c.Enabled {
+				fail("the currency %s is not enabled", currency)
+			}
+			if msg := validateNumber(a, price["amount"]); msg != "" {
+				fail("%s for the currency %s", msg, currency)
+			}
+		}
+	case AttributeTypeBoolean:
+		if _, ok := data.(bool); !ok {
+			fail("a boolean is expected")
+		}
+	case AttributeTypeDate:
+		if msg := validateDate(a, data); msg != "" {
+			fail("%s", msg)
+		}
+	case AttributeTypeSimpleSelect:
+		code, ok := data.(string)
+		if !ok {
+			fail("an option code is expected")
+			return
+		}
+		if msg := v.validateOption(a, code); msg != "" {
+			fail("%s", msg)
+		}
+	case AttributeTypeMultiSelect:
+		codes, ok := stringsOf(data)
+		if !ok {
+			fail("a list of option codes is expected")
+			return
+		}
+		for _, code := range codes {
+			if msg := v.validateOption(a, code); msg != "" {
+				fail("%s", msg)
+			}
+		}
+	case AttributeTypeFile, AttributeTypeImage:
+		file, ok := data.(string)
+		if !ok {
+			fail("a file path is expected")
+			return
+		}
+		if len(a.AllowedExtensions) > 0 {
+			ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(file), "."))
+			if !containsString(a.AllowedExtensions, ext) {
+				fail("the extension %q is not allowed, allowed extensions are %s", ext, strings.Join(a.AllowedExtensions, ", "))
+			}
+		}
+	}
+}
+
+// validateOption checks that an option exists when the options of the attribute are in the schema
+func (v *productValidator) validateOption(a *Attribute, code string) string {
+	if _, loaded := v.schema.AttributeOptions[a.Code]; !loaded {
+		return ""
+	}
+	if _, ok := v.schema.AttributeOption(a.Code, code); !ok {
+		return fmt.Sprintf("the option %s does not exist", code)
+	}
+	return ""
+}
+
+// validateTextRule checks the validation rule of a text attribute
+func validateTextRule(a *Attribute, text string) string {
+	if a.ValidationRule == nil {
+		return ""
+	}
+	switch *a.ValidationRule {
+	case "email":
+		if _, err := mail.ParseAddress(text); err != nil {
+			return "the text is not a valid email"
+		}
+	case "url":
+		if u, err := url.ParseRequestURI(text); err != nil || u.Host == "" {
+			return "the text is not a valid url"
+		}
+	case "regexp":
+		if a.ValidationRegexp == nil {
+			return ""
+		}
+		re, err := compilePimRegexp(*a.ValidationRegexp)
+		if err != nil {
+			// the PCRE syntax is not always supported by go, the PIM checks it
+			return ""
+		}
+		if !re.MatchString(text) {
+			return fmt.Sprintf("the text does not match the regexp %s", *a.ValidationRegexp)
+		}
+	}
+	return ""
+}
+
+// compilePimRegexp compiles a PIM regexp, written with delimiters and flags, i.e. "/^[a-z]+$/i"
+func compilePimRegexp(expr string) (*regexp.Regexp, error) {
+	if len(expr) > 1 {
+		delimiter := expr[:1]
+		if end := strings.LastIndex(expr, delimiter); end > 0 && strings.Trim(delimiter, `/#~@%!|`) == "" {
+			pattern, flags := expr[1:end], expr[end+1:]
+			if strings.ContainsAny(flags, "ims") {
+				pattern = "(?" + strings.Map(func(r rune) rune {
+					if strings.ContainsRune("ims", r) {
+						return r
+					}
+					return -1
+				}, flags) + ")" + pattern
+			}
+			return regexp.Compile(pattern)
+		}
+	}
+	return regexp.Compile(expr)
+}
+
+// validateNumber checks a number against the number constraints of the attribute
+func validateNumber(a *Attribute, data any) string {
+	n, ok := ratOf(data)
+	if !ok {
+		return "a number is expected"
+	}
+	if (a.DecimalsAllowed == nil || !*a.DecimalsAllowed) && !n.IsInt() {
+		return "decimals are not allowed"
+	}
+	if a.NegativeAllowed != nil && !*a.NegativeAllowed && n.Sign() < 0 {
+		return "negative numbers are not allowed"
+	}
+	if a.NumberMin != nil && *a.NumberMin != "" {
+		if min, ok := new(big.Rat).SetString(*a.NumberMin); ok && n.Cmp(min) < 0 {
+			return fmt.Sprintf("the number is lower than the minimum %s", *a.NumberMin)
+		}
+	}
+	if a.NumberMax != nil && *a.NumberMax != "" {
+		if max, ok := new(big.Rat).SetString(*a.NumberMax); ok && n.Cmp(max) > 0 {
+			return fmt.Sprintf("the number is greater than the maximum %s", *a.NumberMax)
+		}
+	}
+	return ""
+}
+
+// validateDate checks a date against the date constraints of the attribute
+func validateDate(a *Attribute, data any) string {
+	text, ok := data.(string)
+	if !ok {
+		return "a date is expected"
+	}
+	date, ok := parsePimDate(text)
+	if !ok {
+		return "the date is not in the ISO-8601 format"
+	}
+	if a.DateMin != nil {
+		if min, ok := parsePimDate(*a.DateMin); ok && date.Before(min) {
+			return fmt.Sprintf("the date is before the minimum %s", *a.DateMin)
+		}
+	}
+	if a.DateMax != nil {
+		if max, ok := parsePimDate(*a.DateMax); ok && date.After(max) {
+			return fmt.Sprintf("the date is after the maximum %s", *a.DateMax)
+		}
+	}
+	return ""
+}
+
+func parsePimDate(text string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, text); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// normalizeData returns the data as decoded from json, so that the values built with structs
+// are checked like the values received from the PIM, numbers are decoded as json.Number
+func normalizeData(data any) any {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return data
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var normalized any
+	if err := decoder.Decode(&normalized); err != nil {
+		return data
+	}
+	return normalized
+}
+
+// ratOf returns the number of a value data, numbers can be sent as numbers or decimal strings
+func ratOf(data any) (*big.Rat, bool) {
+	switch n := data.(type) {
+	case string:
+		return new(big.Rat).SetString(n)
+	case json.Number:
+		return new(big.Rat).SetString(n.String())
+	case float64:
+		r := new(big.Rat)
+		if r.SetFloat64(n) == nil {
+			return nil, false
+		}
+		return r, true
+	case float32:
+		return ratOf(float64(n))
+	case int:
+		return new(big.Rat).SetInt64(int64(n)), true
+	case int32:
+		return new(big.Rat).SetInt64(int64(n)), true
+	case int64:
+		return new(big.Rat).SetInt64(n), true
+	default:
+		return nil, false
+	}
+}
+
+// stringsOf returns the strings of a []string or a []any of strings
+func stringsOf(data any) ([]string, bool) {
+	switch values := data.(type) {
+	case []string:
+		return values, true
+	case []any:
+		result := make([]string, 0, len(values))
+		for _, value := range values {
+			s, ok := value.(string)
+			if !ok {
+				return nil, false
+			}
+			result = append(result, s)
+		}
+		return result, true
+	default:
+		return nil, false
+	}
+}
+
+func stringOf(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
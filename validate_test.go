@@ -0,0 +1,99 @@
+package goakeneo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testValidationSchema() *Schema {
+	yes, no := true, false
+	maxChars := 10
+	rule, re := "regexp", "/^[A-Z]+$/"
+	min, max := "0", "100"
+	kg := "Weight"
+	return &Schema{
+		Attributes: []Attribute{
+			{Code: "sku", Type: AttributeTypeIdentifier},
+			{Code: "name", Type: AttributeTypeText, Localizable: &yes, MaxCharacters: &maxChars},
+			{Code: "ref", Type: AttributeTypeText, ValidationRule: &rule, ValidationRegexp: &re},
+			{Code: "stock", Type: AttributeTypeNumber, DecimalsAllowed: &no, NegativeAllowed: &no, NumberMin: &min, NumberMax: &max},
+			{Code: "weight", Type: AttributeTypeMetric, DecimalsAllowed: &yes, MetricFamily: &kg},
+			{Code: "price", Type: AttributeTypePrice, Scopable: &yes, DecimalsAllowed: &yes},
+			{Code: "color", Type: AttributeTypeSimpleSelect},
+			{Code: "picture", Type: AttributeTypeImage, AllowedExtensions: []string{"jpg", "png"}},
+			{Code: "legal", Type: AttributeTypeTextarea, Localizable: &yes, AvailableLocales: []string{"fr_FR"}},
+		},
+		AttributeOptions: map[string][]AttributeOption{"color": {{Code: "red"}}},
+		Families: []Family{{
+			Code:       "shirts",
+			Attributes: []string{"sku", "name", "ref", "stock", "weight", "price", "color", "picture"},
+		}},
+		Channels:   []Channel{{Code: "ecommerce", Locales: []string{"en_US"}, Currencies: []string{"EUR"}}},
+		Locales:    []Locale{{Code: "en_US", Enabled: true}, {Code: "fr_FR", Enabled: true}},
+		Currencies: []Currency{{Code: "EUR", Enabled: true}, {Code: "USD"}},
+		MeasurementFamilies: []MeasurementFamily{{
+			Code:  "Weight",
+			Units: map[string]MeasurementUnit{"KILOGRAM": {Code: "KILOGRAM"}},
+		}},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	schema := testValidationSchema()
+	enUS, ecommerce := "en_US", "ecommerce"
+	valid := Product{
+		Identifier: "sku_1",
+		Family:     "shirts",
+		Values: map[string][]ProductValue{
+			"name":    {{Locale: &enUS, Data: "Shirt"}},
+			"ref":     {{Data: "ABC"}},
+			"stock":   {{Data: 10}},
+			"weight":  {{Data: map[string]any{"amount": "1.5", "unit": "KILOGRAM"}}},
+			"price":   {{Scope: &ecommerce, Data: []map[string]any{{"amount": "9.99", "currency": "EUR"}}}},
+			"color":   {{Data: "red"}},
+			"picture": {{Data: "a/b/c/shirt.JPG"}},
+		},
+	}
+	assert.Empty(t, Validate(valid, schema))
+
+	invalid := Product{
+		Identifier: "sku_2",
+		Family:     "shirts",
+		Values: map[string][]ProductValue{
+			"name":    {{Data: "A shirt far too long"}},
+			"ref":     {{Data: "abc"}},
+			"stock":   {{Data: "-1.5"}},
+			"weight":  {{Data: map[string]any{"amount": "1", "unit": "POUND"}}},
+			"price":   {{Data: []map[string]any{{"amount": "9.99", "currency": "USD"}}}},
+			"color":   {{Data: "blue"}},
+			"picture": {{Data: "shirt.gif"}},
+			"legal":   {{Locale: &enUS, Data: "text"}},
+			"unknown": {{Data: "x"}},
+		},
+	}
+	errs := Validate(invalid, schema)
+	byAttribute := make(map[string][]string)
+	for _, e := range errs {
+		byAttribute[e.Attribute] = append(byAttribute[e.Attribute], e.Message)
+		assert.NotEmpty(t, e.Error())
+	}
+	assert.ElementsMatch(t, []string{
+		"the attribute is localizable, a locale is expected",
+		"the text is longer than 10 characters",
+	}, byAttribute["name"])
+	assert.Len(t, byAttribute["ref"], 1)
+	assert.Equal(t, []string{"decimals are not allowed"}, byAttribute["stock"])
+	assert.Equal(t, []string{"the unit POUND does not belong to the measurement family Weight"}, byAttribute["weight"])
+	assert.ElementsMatch(t, []string{
+		"the attribute is scopable, a scope is expected",
+		"the currency USD is not enabled",
+	}, byAttribute["price"])
+	assert.Equal(t, []string{"the option blue does not exist"}, byAttribute["color"])
+	assert.Len(t, byAttribute["picture"], 1)
+	assert.ElementsMatch(t, []string{
+		"the attribute does not belong to the family shirts",
+		"the locale en_US is not available for this locale specific attribute",
+	}, byAttribute["legal"])
+	assert.Equal(t, []string{"the attribute does not exist"}, byAttribute["unknown"])
+}